@@ -0,0 +1,217 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Handle refers to a resource created by a command in a Batch. Before the
+// batch is committed, Ref returns the temporary ID of the resource, which can
+// be used as an argument of later commands in the same batch. After the batch
+// is committed, the handle resolves to the real ID assigned by Todoist.
+type Handle struct {
+	TempID string
+	id     string
+}
+
+// Ref returns the real ID of the resource if it is known, or its temporary ID
+// otherwise.
+func (h *Handle) Ref() string {
+	if h.id != "" {
+		return h.id
+	}
+	return h.TempID
+}
+
+// ID returns the real ID of the resource and whether it has been resolved.
+func (h *Handle) ID() (string, bool) {
+	return h.id, h.id != ""
+}
+
+// Batch collects commands that are sent together, where later commands can
+// refer to resources created by earlier ones.
+//
+// Example:
+//
+//	batch := client.Sync.NewBatch()
+//	project := batch.Add(todoist.NewProjectAddCommand(todoist.ProjectAddArgs{
+//	 Name: "Groceries",
+//	}))
+//	batch.Add(todoist.NewItemAddCommand(todoist.ItemAddArgs{
+//	 Content:   "Milk",
+//	 ProjectID: project.Ref(),
+//	}))
+//	result, err := batch.Commit(ctx)
+//	projectID, _ := project.ID()
+type Batch struct {
+	sync     *Sync
+	commands []Command
+	handles  map[string]*Handle // Keyed by temporary ID
+}
+
+// NewBatch returns an empty batch that is committed through s.
+func (s *Sync) NewBatch() *Batch {
+	return &Batch{
+		sync:    s,
+		handles: map[string]*Handle{},
+	}
+}
+
+// Add appends a command to the batch. If the command creates a resource, the
+// returned handle refers to it. Otherwise the returned handle is nil.
+func (b *Batch) Add(command Command) *Handle {
+	b.commands = append(b.commands, command)
+	if command.TempID == "" {
+		return nil
+	}
+
+	handle := &Handle{TempID: command.TempID}
+	b.handles[command.TempID] = handle
+	return handle
+}
+
+// Commands returns the commands that have been added to the batch.
+func (b *Batch) Commands() []Command {
+	return b.commands
+}
+
+// Lookup returns the real ID for a temporary ID created in the batch, and
+// whether it has been resolved.
+func (b *Batch) Lookup(tempID string) (string, bool) {
+	handle, ok := b.handles[tempID]
+	if !ok {
+		return "", false
+	}
+	return handle.ID()
+}
+
+// Validate checks that temporary IDs are unique and that every temporary ID
+// referenced by a command is created by an earlier command in the batch.
+//
+// A value is considered a temporary ID reference when it is passed as an ID
+// argument (id, any *_id argument, or a key of an ID to order mapping) and it
+// either matches a temporary ID of the batch or has the form of a UUID, which
+// real Todoist IDs never have.
+func (b *Batch) Validate() error {
+	defined := map[string]int{}
+	for i, command := range b.commands {
+		if command.TempID == "" {
+			continue
+		}
+		if j, ok := defined[command.TempID]; ok {
+			return fmt.Errorf(
+				"%w: commands %d and %d use the same temp_id %q",
+				ErrInvalidArgument,
+				j,
+				i,
+				command.TempID,
+			)
+		}
+		defined[command.TempID] = i
+	}
+
+	for i, command := range b.commands {
+		for _, ref := range idReferences(command.Args) {
+			j, ok := defined[ref]
+			if !ok {
+				if !isUUID(ref) {
+					continue
+				}
+				if _, resolved := b.Lookup(ref); resolved {
+					continue
+				}
+				return fmt.Errorf(
+					"%w: command %d (%s) references undefined temp_id %q",
+					ErrInvalidArgument,
+					i,
+					command.Type,
+					ref,
+				)
+			}
+			if j >= i {
+				return fmt.Errorf(
+					"%w: command %d (%s) references temp_id %q "+
+						"before command %d creates it",
+					ErrInvalidArgument,
+					i,
+					command.Type,
+					ref,
+					j,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit validates the batch and sends its commands on their own, without
+// the commands queued on the Sync. The handles of the batch are resolved from
+// the returned temp_id_mapping, including when a later chunk of commands fails
+// to be sent. Commands that fail are reported by the returned error and by
+// SyncWriteResult.Failed, and are not queued. The commands are removed from
+// the batch, so it can be reused for a new set of commands.
+func (b *Batch) Commit(ctx context.Context) (*SyncWriteResult, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	commands := b.commands
+	b.commands = nil
+
+	result, err := b.sync.execute(ctx, commands...)
+	if result != nil {
+		b.Resolve(result.TempIDMapping)
+	}
+	return result, err
+}
+
+// Resolve updates the handles of the batch from a temp_id_mapping. It is
+// called by Commit, and can be used when the commands of the batch were sent
+// by other means.
+func (b *Batch) Resolve(tempIDMapping map[string]string) {
+	for tempID, id := range tempIDMapping {
+		if handle, ok := b.handles[tempID]; ok {
+			handle.id = id
+		}
+	}
+}
+
+// idReferences returns the string values passed as ID arguments in args.
+func idReferences(args map[string]any) []string {
+	var refs []string
+	var walk func(key string, value any)
+	walk = func(key string, value any) {
+		isIDKey := key == "id" || strings.HasSuffix(key, "_id")
+		isOrderMapping := key == "ids_to_orders" || key == "id_order_mapping"
+		switch v := value.(type) {
+		case string:
+			if isIDKey {
+				refs = append(refs, v)
+			}
+		case []any:
+			for _, item := range v {
+				walk(key, item)
+			}
+		case []string:
+			for _, item := range v {
+				walk(key, item)
+			}
+		case map[string]int:
+			if isOrderMapping {
+				for id := range v {
+					refs = append(refs, id)
+				}
+			}
+		case map[string]any:
+			for k, item := range v {
+				if isOrderMapping {
+					refs = append(refs, k)
+				}
+				walk(k, item)
+			}
+		}
+	}
+	walk("", args)
+	return refs
+}
@@ -0,0 +1,111 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBatchValidate(t *testing.T) {
+	project := NewProjectAddCommand(ProjectAddArgs{Name: "Groceries"})
+	item := NewItemAddCommand(ItemAddArgs{
+		Content:   "Milk",
+		ProjectID: project.TempID,
+	})
+	duplicate := project
+	duplicate.UUID = newUUID()
+
+	tests := []struct {
+		name     string
+		commands []Command
+		wantErr  bool
+	}{
+		{"defined before use", []Command{project, item}, false},
+		{"used before defined", []Command{item, project}, true},
+		{"undefined", []Command{item}, true},
+		{"duplicate temp_id", []Command{project, duplicate}, true},
+		{"real IDs", []Command{NewItemCloseCommand("123")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := (&Sync{}).NewBatch()
+			for _, command := range tt.commands {
+				batch.Add(command)
+			}
+			err := batch.Validate()
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Validate() error = %v, want error %t", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("error = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestBatchResolveAndLookup(t *testing.T) {
+	batch := (&Sync{}).NewBatch()
+	project := batch.Add(NewProjectAddCommand(ProjectAddArgs{Name: "Groceries"}))
+	if handle := batch.Add(NewItemCloseCommand("1")); handle != nil {
+		t.Errorf("handle of item_close = %v, want nil", handle)
+	}
+
+	if _, ok := project.ID(); ok {
+		t.Error("handle is resolved before Resolve")
+	}
+	if project.Ref() != project.TempID {
+		t.Errorf("Ref() = %q, want the temp ID", project.Ref())
+	}
+
+	batch.Resolve(map[string]string{project.TempID: "123", "other": "456"})
+	if id, ok := project.ID(); !ok || id != "123" {
+		t.Errorf("ID() = %q, %t, want %q, true", id, ok, "123")
+	}
+	if project.Ref() != "123" {
+		t.Errorf("Ref() = %q, want %q", project.Ref(), "123")
+	}
+	if id, ok := batch.Lookup(project.TempID); !ok || id != "123" {
+		t.Errorf("Lookup() = %q, %t, want %q, true", id, ok, "123")
+	}
+	if _, ok := batch.Lookup("other"); ok {
+		t.Error("Lookup() resolved a temp ID that is not in the batch")
+	}
+}
+
+func TestBatchCommitLeavesQueueAlone(t *testing.T) {
+	server := &syncServer{fail: []string{CommandItemClose}}
+	c := newSyncTestClient(t, server)
+	queued := NewItemCloseCommand("queued")
+	c.Sync.AddCommand(queued)
+
+	batch := c.Sync.NewBatch()
+	project := batch.Add(NewProjectAddCommand(ProjectAddArgs{Name: "Groceries"}))
+	batch.Add(NewItemAddCommand(ItemAddArgs{
+		Content:   "Milk",
+		ProjectID: project.Ref(),
+	}))
+
+	result, err := batch.Commit(context.Background())
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if len(result.Applied) != 2 || len(result.Failed) != 0 {
+		t.Errorf(
+			"applied %d and failed %d commands, want 2 and 0",
+			len(result.Applied),
+			len(result.Failed),
+		)
+	}
+	if id, _ := project.ID(); id != "real-"+project.TempID {
+		t.Errorf("project ID = %q, want %q", id, "real-"+project.TempID)
+	}
+	if len(server.requests) != 1 || len(server.requests[0]) != 2 {
+		t.Errorf("sent %v, want only the 2 commands of the batch", server.requests)
+	}
+	if len(c.Sync.Commands) != 1 || c.Sync.Commands[0].UUID != queued.UUID {
+		t.Errorf("queue = %v, want only the command queued before", c.Sync.Commands)
+	}
+	if len(batch.Commands()) != 0 {
+		t.Errorf("%d commands left in the batch, want 0", len(batch.Commands()))
+	}
+}
@@ -0,0 +1,100 @@
+// Package boltkv implements todoist.KeyValueStore with a bbolt database, so
+// that a todoist.Store can keep its state in an embedded key-value database.
+// It is a separate module, so that only programs importing it depend on bbolt.
+//
+// Example:
+//
+//	kv, err := boltkv.Open("todoist.db", "state")
+//	if err != nil {
+//	 return err
+//	}
+//	defer kv.Close()
+//
+//	store, err := todoist.OpenStore(ctx, todoist.NewKVStateStore(kv, "replica"))
+package boltkv
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Esteban-Bermudez/todoist-go/pkg/todoist"
+	bolt "go.etcd.io/bbolt"
+)
+
+// KV stores values in a bucket of a bbolt database. Every Put is committed in
+// its own transaction, which bbolt syncs to disk before it returns.
+type KV struct {
+	db     *bolt.DB
+	bucket []byte
+	owned  bool // The database was opened by Open
+}
+
+var _ todoist.KeyValueStore = (*KV)(nil)
+
+// Open opens the bbolt database at path, creating it if needed, and returns a
+// KV using the given bucket. bbolt locks the file, so Open waits at most one
+// second for another process to release it.
+func Open(path string, bucket string) (*KV, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("%w: bucket is required", todoist.ErrInvalidArgument)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	kv, err := New(db, bucket)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	kv.owned = true
+	return kv, nil
+}
+
+// New returns a KV using the given bucket of an open database, creating the
+// bucket if needed. The database is not closed by Close.
+func New(db *bolt.DB, bucket string) (*KV, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+	return &KV{db: db, bucket: []byte(bucket)}, nil
+}
+
+// Get returns the value of key, or todoist.ErrStateNotFound if the key does
+// not exist.
+func (kv *KV) Get(key string) ([]byte, error) {
+	var value []byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(kv.bucket).Get([]byte(key))
+		if data == nil {
+			return todoist.ErrStateNotFound
+		}
+		// Values are only valid during the transaction.
+		value = append([]byte(nil), data...)
+		return nil
+	})
+	return value, err
+}
+
+// Put sets the value of key.
+func (kv *KV) Put(key string, value []byte) error {
+	if key == "" {
+		return fmt.Errorf("%w: key is required", todoist.ErrInvalidArgument)
+	}
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(kv.bucket).Put([]byte(key), value)
+	})
+}
+
+// Close closes the database if it was opened by Open.
+func (kv *KV) Close() error {
+	if !kv.owned {
+		return nil
+	}
+	return kv.db.Close()
+}
@@ -0,0 +1,65 @@
+package boltkv
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Esteban-Bermudez/todoist-go/pkg/todoist"
+)
+
+func TestKV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todoist.db")
+	kv, err := Open(path, "state")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if _, err := kv.Get("missing"); !errors.Is(err, todoist.ErrStateNotFound) {
+		t.Errorf("Get error = %v, want todoist.ErrStateNotFound", err)
+	}
+	if err := kv.Put("key", []byte("value")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := kv.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	kv, err = Open(path, "state")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer kv.Close()
+	value, err := kv.Get("key")
+	if err != nil || string(value) != "value" {
+		t.Errorf("Get = %q, %v, want %q", value, err, "value")
+	}
+}
+
+func TestKVStateStore(t *testing.T) {
+	ctx := context.Background()
+	kv, err := Open(filepath.Join(t.TempDir(), "todoist.db"), "state")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer kv.Close()
+	ss := todoist.NewKVStateStore(kv, "replica")
+
+	st, err := todoist.OpenStore(ctx, ss)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	st.Apply(&todoist.SyncReadResponse{SyncToken: "token", FullSync: true})
+	if err := st.Save(ctx); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored, err := todoist.OpenStore(ctx, ss)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if restored.SyncToken() != "token" {
+		t.Errorf("SyncToken = %q, want %q", restored.SyncToken(), "token")
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/Esteban-Bermudez/todoist-go/pkg/todoist/boltkv
+
+go 1.23.5
+
+require (
+	github.com/Esteban-Bermudez/todoist-go v0.0.0-00010101000000-000000000000
+	go.etcd.io/bbolt v1.4.3
+)
+
+require golang.org/x/sys v0.29.0 // indirect
+
+replace github.com/Esteban-Bermudez/todoist-go => ../../..
@@ -5,44 +5,55 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 )
 
-type APIError struct {
-	Status       string
-	ResponseBody []byte
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf(
-		"API error: status %s, response: %s",
-		e.Status,
-		string(e.ResponseBody)[:100], // Limit to first 100 characters for readability
-	)
-}
+// DefaultBaseURL is the base URL of the Todoist API v1 used by NewClient.
+const DefaultBaseURL = "https://api.todoist.com/api/v1"
 
 // Client represents a Todoist API client. It contains the API key and base URL
 // for making requests to the Todoist API.
 type Client struct {
-	BaseURL string
-	Sync    *Sync
+	BaseURL    string
+	Sync       *Sync
+	HTTPClient *http.Client
+	UserAgent  string // Sent as the User-Agent header when not empty
+
+	// RetryPolicy controls how requests that fail with a transient error are
+	// retried. A nil policy disables retries.
+	RetryPolicy *RetryPolicy
 }
 
 // NewClient creates a new Todoist API client with the provided API key.
 // The base URL is set to the Todoist API v1 endpoint and will be updated when
-// the API version changes.
-func NewClient(apiKey string) *Client {
-	return &Client{
-		BaseURL: "https://api.todoist.com/api/v1",
+// the API version changes. Options can be passed to change the defaults.
+//
+// Example:
+//
+//	client := todoist.NewClient(
+//	 apiKey,
+//	 todoist.WithBaseURL("http://localhost:8080/api/v1"),
+//	 todoist.WithTimeout(10*time.Second),
+//	)
+func NewClient(apiKey string, options ...Option) *Client {
+	c := &Client{
+		BaseURL: DefaultBaseURL,
 		Sync: &Sync{
 			SyncToken:     "*",
 			ResourceTypes: []string{},
 			Commands:      []Command{},
 			APIKey:        apiKey,
 		},
+		HTTPClient:  &http.Client{Timeout: DefaultTimeout},
+		RetryPolicy: DefaultRetryPolicy(),
 	}
+	c.Sync.client = c
+
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
 func (c *Client) request(
@@ -82,20 +93,31 @@ func (c *Client) request(
 	}
 	req.Header.Set("Authorization", "Bearer "+c.Sync.APIKey)
 	req.Header.Set("Content-Type", "application/json")
+	if method != http.MethodGet {
+		req.Header.Set("X-Request-Id", newUUID())
+	}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	return c.do(req)
+}
+
+// do sends the request, retrying it according to the client's RetryPolicy.
+// Responses with a status of 400 or above are returned as an *APIError.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	res, err := doWithRetry(client, c.RetryPolicy, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res.StatusCode >= 400 {
-		resBody, _ := io.ReadAll(res.Body)
-		res.Body.Close()
-		return nil, &APIError{
-			Status:       res.Status,
-			ResponseBody: resBody,
-		}
+		return nil, newAPIError(req, res)
 	}
 
 	return res, nil
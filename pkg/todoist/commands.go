@@ -0,0 +1,851 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Command types supported by the Sync API.
+const (
+	CommandItemAdd                = "item_add"
+	CommandItemUpdate             = "item_update"
+	CommandItemMove               = "item_move"
+	CommandItemReorder            = "item_reorder"
+	CommandItemDelete             = "item_delete"
+	CommandItemClose              = "item_close"
+	CommandItemComplete           = "item_complete"
+	CommandItemUncomplete         = "item_uncomplete"
+	CommandItemUpdateDateComplete = "item_update_date_complete"
+	CommandItemUpdateDayOrders    = "item_update_day_orders"
+
+	CommandProjectAdd             = "project_add"
+	CommandProjectUpdate          = "project_update"
+	CommandProjectMove            = "project_move"
+	CommandProjectMoveToWorkspace = "project_move_to_workspace"
+	CommandProjectMoveToPersonal  = "project_move_to_personal"
+	CommandProjectReorder         = "project_reorder"
+	CommandProjectDelete          = "project_delete"
+	CommandProjectArchive         = "project_archive"
+	CommandProjectUnarchive       = "project_unarchive"
+	CommandProjectLeave           = "project_leave"
+
+	CommandSectionAdd       = "section_add"
+	CommandSectionUpdate    = "section_update"
+	CommandSectionMove      = "section_move"
+	CommandSectionReorder   = "section_reorder"
+	CommandSectionDelete    = "section_delete"
+	CommandSectionArchive   = "section_archive"
+	CommandSectionUnarchive = "section_unarchive"
+
+	CommandLabelAdd               = "label_add"
+	CommandLabelUpdate            = "label_update"
+	CommandLabelDelete            = "label_delete"
+	CommandLabelRename            = "label_rename"
+	CommandLabelDeleteOccurrences = "label_delete_occurrences"
+	CommandLabelUpdateOrders      = "label_update_orders"
+
+	CommandNoteAdd           = "note_add"
+	CommandNoteUpdate        = "note_update"
+	CommandNoteDelete        = "note_delete"
+	CommandProjectNoteAdd    = "project_note_add"
+	CommandProjectNoteUpdate = "project_note_update"
+	CommandProjectNoteDelete = "project_note_delete"
+
+	CommandFilterAdd          = "filter_add"
+	CommandFilterUpdate       = "filter_update"
+	CommandFilterDelete       = "filter_delete"
+	CommandFilterUpdateOrders = "filter_update_orders"
+
+	CommandReminderAdd    = "reminder_add"
+	CommandReminderUpdate = "reminder_update"
+	CommandReminderDelete = "reminder_delete"
+
+	CommandShareProject       = "share_project"
+	CommandDeleteCollaborator = "delete_collaborator"
+	CommandAcceptInvitation   = "accept_invitation"
+	CommandRejectInvitation   = "reject_invitation"
+	CommandDeleteInvitation   = "delete_invitation"
+
+	CommandUserUpdate      = "user_update"
+	CommandUserUpdateGoals = "update_goals"
+
+	CommandWorkspaceAdd        = "workspace_add"
+	CommandWorkspaceUpdate     = "workspace_update"
+	CommandWorkspaceDelete     = "workspace_delete"
+	CommandWorkspaceLeave      = "workspace_leave"
+	CommandWorkspaceInvite     = "workspace_invite"
+	CommandWorkspaceUpdateUser = "workspace_update_user"
+	CommandWorkspaceDeleteUser = "workspace_delete_user"
+)
+
+// newCommand builds a command of the given type with a new UUID. The args are
+// encoded to the map stored in Command.Args using their JSON tags.
+func newCommand(commandType string, args any) Command {
+	return Command{
+		Type: commandType,
+		Args: commandArgs(args),
+		UUID: newUUID(),
+	}
+}
+
+// newAddCommand builds a command that creates a resource. It is given a
+// generated temporary ID that other commands in the same request can use to
+// refer to the new resource.
+func newAddCommand(commandType string, args any) Command {
+	command := newCommand(commandType, args)
+	command.TempID = newUUID()
+	return command
+}
+
+// commandArgs encodes args into a map through its JSON representation. The
+// args types in this file always encode to a JSON object, so failing to do so
+// is a programming error.
+func commandArgs(args any) map[string]any {
+	data, err := json.Marshal(args)
+	if err != nil {
+		panic(fmt.Sprintf("todoist: cannot encode %T command args: %v", args, err))
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		panic(fmt.Sprintf("todoist: cannot encode %T command args: %v", args, err))
+	}
+	return m
+}
+
+// idArgs is used by commands that only take the ID of a resource.
+type idArgs struct {
+	ID string `json:"id"`
+}
+
+// WithTempID returns a copy of the command using the given temporary ID
+// instead of the generated one.
+func (c Command) WithTempID(tempID string) Command {
+	c.TempID = tempID
+	return c
+}
+
+// DueArgs sets the due date of a task or reminder. Either String or Date
+// should be set. When String is set, the date is parsed by Todoist using Lang.
+type DueArgs struct {
+	String      string `json:"string,omitempty"`
+	Date        string `json:"date,omitempty"` // YYYY-MM-DD or a date time in RFC 3339 format
+	Timezone    string `json:"timezone,omitempty"`
+	Lang        string `json:"lang,omitempty"`
+	IsRecurring *bool  `json:"is_recurring,omitempty"`
+}
+
+// ItemAddArgs holds the arguments of the item_add command.
+type ItemAddArgs struct {
+	Content         string    `json:"content"`
+	Description     string    `json:"description,omitempty"`
+	ProjectID       string    `json:"project_id,omitempty"`
+	Due             *DueArgs  `json:"due,omitempty"`
+	Deadline        *Deadline `json:"deadline,omitempty"`
+	Priority        int       `json:"priority,omitempty"`
+	ParentID        string    `json:"parent_id,omitempty"`
+	ChildOrder      int       `json:"child_order,omitempty"`
+	SectionID       string    `json:"section_id,omitempty"`
+	DayOrder        int       `json:"day_order,omitempty"`
+	IsCollapsed     bool      `json:"is_collapsed,omitempty"`
+	Labels          []string  `json:"labels,omitempty"`
+	AssignedByUID   string    `json:"assigned_by_uid,omitempty"`
+	ResponsibleUID  string    `json:"responsible_uid,omitempty"`
+	AutoReminder    bool      `json:"auto_reminder,omitempty"`
+	AutoParseLabels bool      `json:"auto_parse_labels,omitempty"`
+	Duration        *Duration `json:"duration,omitempty"`
+}
+
+// NewItemAddCommand returns an item_add command that creates a task.
+func NewItemAddCommand(args ItemAddArgs) Command {
+	return newAddCommand(CommandItemAdd, args)
+}
+
+// ItemUpdateArgs holds the arguments of the item_update command. Only the
+// fields that are not nil are updated.
+type ItemUpdateArgs struct {
+	ID             string    `json:"id"`
+	Content        *string   `json:"content,omitempty"`
+	Description    *string   `json:"description,omitempty"`
+	Due            *DueArgs  `json:"due,omitempty"`
+	Deadline       *Deadline `json:"deadline,omitempty"`
+	Priority       *int      `json:"priority,omitempty"`
+	IsCollapsed    *bool     `json:"is_collapsed,omitempty"`
+	Labels         *[]string `json:"labels,omitempty"`
+	AssignedByUID  *string   `json:"assigned_by_uid,omitempty"`
+	ResponsibleUID *string   `json:"responsible_uid,omitempty"`
+	DayOrder       *int      `json:"day_order,omitempty"`
+	Duration       *Duration `json:"duration,omitempty"`
+}
+
+// NewItemUpdateCommand returns an item_update command that updates a task.
+func NewItemUpdateCommand(args ItemUpdateArgs) Command {
+	return newCommand(CommandItemUpdate, args)
+}
+
+// ItemMoveArgs holds the arguments of the item_move command. Exactly one of
+// ParentID, SectionID or ProjectID must be set.
+type ItemMoveArgs struct {
+	ID        string `json:"id"`
+	ParentID  string `json:"parent_id,omitempty"`
+	SectionID string `json:"section_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// NewItemMoveCommand returns an item_move command that moves a task, along
+// with its subtasks, to another parent, section or project.
+func NewItemMoveCommand(args ItemMoveArgs) Command {
+	return newCommand(CommandItemMove, args)
+}
+
+// ItemOrder sets the position of a task among its siblings.
+type ItemOrder struct {
+	ID         string `json:"id"`
+	ChildOrder int    `json:"child_order"`
+}
+
+// NewItemReorderCommand returns an item_reorder command that updates the
+// child_order of the given sibling tasks.
+func NewItemReorderCommand(items []ItemOrder) Command {
+	return newCommand(CommandItemReorder, struct {
+		Items []ItemOrder `json:"items"`
+	}{items})
+}
+
+// NewItemDeleteCommand returns an item_delete command that deletes a task
+// and all of its subtasks.
+func NewItemDeleteCommand(id string) Command {
+	return newCommand(CommandItemDelete, idArgs{id})
+}
+
+// NewItemCloseCommand returns an item_close command that completes a task
+// the same way the official clients do: recurring tasks are moved to their
+// next occurrence and regular tasks are completed.
+func NewItemCloseCommand(id string) Command {
+	return newCommand(CommandItemClose, idArgs{id})
+}
+
+// ItemCompleteArgs holds the arguments of the item_complete command.
+type ItemCompleteArgs struct {
+	ID            string `json:"id"`
+	DateCompleted string `json:"date_completed,omitempty"` // RFC 3339, defaults to now
+}
+
+// NewItemCompleteCommand returns an item_complete command that completes a
+// task and its subtasks.
+func NewItemCompleteCommand(args ItemCompleteArgs) Command {
+	return newCommand(CommandItemComplete, args)
+}
+
+// NewItemUncompleteCommand returns an item_uncomplete command that reopens a
+// completed task.
+func NewItemUncompleteCommand(id string) Command {
+	return newCommand(CommandItemUncomplete, idArgs{id})
+}
+
+// ItemUpdateDateCompleteArgs holds the arguments of the
+// item_update_date_complete command.
+type ItemUpdateDateCompleteArgs struct {
+	ID            string   `json:"id"`
+	Due           *DueArgs `json:"due,omitempty"`
+	IsForward     *bool    `json:"is_forward,omitempty"`
+	ResetSubtasks *bool    `json:"reset_subtasks,omitempty"`
+}
+
+// NewItemUpdateDateCompleteCommand returns an item_update_date_complete
+// command that completes an occurrence of a recurring task.
+func NewItemUpdateDateCompleteCommand(args ItemUpdateDateCompleteArgs) Command {
+	return newCommand(CommandItemUpdateDateComplete, args)
+}
+
+// NewItemUpdateDayOrdersCommand returns an item_update_day_orders command that
+// sets the order of tasks in the Today and Next 7 days views. idsToOrders maps
+// task IDs to their day_order.
+func NewItemUpdateDayOrdersCommand(idsToOrders map[string]int) Command {
+	return newCommand(CommandItemUpdateDayOrders, struct {
+		IDsToOrders map[string]int `json:"ids_to_orders"`
+	}{idsToOrders})
+}
+
+// ProjectAddArgs holds the arguments of the project_add command.
+type ProjectAddArgs struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	ParentID    string `json:"parent_id,omitempty"`
+	ChildOrder  int    `json:"child_order,omitempty"`
+	IsFavorite  bool   `json:"is_favorite,omitempty"`
+	ViewStyle   string `json:"view_style,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+// NewProjectAddCommand returns a project_add command that creates a project.
+func NewProjectAddCommand(args ProjectAddArgs) Command {
+	return newAddCommand(CommandProjectAdd, args)
+}
+
+// ProjectUpdateArgs holds the arguments of the project_update command. Only
+// the fields that are not nil are updated.
+type ProjectUpdateArgs struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	IsCollapsed *bool   `json:"is_collapsed,omitempty"`
+	IsFavorite  *bool   `json:"is_favorite,omitempty"`
+	ViewStyle   *string `json:"view_style,omitempty"`
+}
+
+// NewProjectUpdateCommand returns a project_update command that updates a
+// project.
+func NewProjectUpdateCommand(args ProjectUpdateArgs) Command {
+	return newCommand(CommandProjectUpdate, args)
+}
+
+// NewProjectMoveCommand returns a project_move command that moves a project
+// under a new parent. An empty parentID moves the project to the root level.
+func NewProjectMoveCommand(id string, parentID string) Command {
+	return newCommand(CommandProjectMove, struct {
+		ID       string  `json:"id"`
+		ParentID *string `json:"parent_id"`
+	}{id, nullableString(parentID)})
+}
+
+// ProjectMoveToWorkspaceArgs holds the arguments of the
+// project_move_to_workspace command.
+type ProjectMoveToWorkspaceArgs struct {
+	ProjectID   string `json:"project_id"`
+	WorkspaceID string `json:"workspace_id"`
+	FolderID    string `json:"folder_id,omitempty"`
+	IsCollapsed *bool  `json:"is_collapsed,omitempty"`
+}
+
+// NewProjectMoveToWorkspaceCommand returns a project_move_to_workspace command
+// that moves a personal project, with its subprojects, into a workspace.
+func NewProjectMoveToWorkspaceCommand(args ProjectMoveToWorkspaceArgs) Command {
+	return newCommand(CommandProjectMoveToWorkspace, args)
+}
+
+// NewProjectMoveToPersonalCommand returns a project_move_to_personal command
+// that moves a workspace project back to the user's personal projects.
+func NewProjectMoveToPersonalCommand(projectID string) Command {
+	return newCommand(CommandProjectMoveToPersonal, struct {
+		ProjectID string `json:"project_id"`
+	}{projectID})
+}
+
+// ProjectOrder sets the position of a project among its siblings.
+type ProjectOrder struct {
+	ID         string `json:"id"`
+	ChildOrder int    `json:"child_order"`
+}
+
+// NewProjectReorderCommand returns a project_reorder command that updates the
+// child_order of the given sibling projects.
+func NewProjectReorderCommand(projects []ProjectOrder) Command {
+	return newCommand(CommandProjectReorder, struct {
+		Projects []ProjectOrder `json:"projects"`
+	}{projects})
+}
+
+// NewProjectDeleteCommand returns a project_delete command that deletes a
+// project and all of its descendants.
+func NewProjectDeleteCommand(id string) Command {
+	return newCommand(CommandProjectDelete, idArgs{id})
+}
+
+// NewProjectArchiveCommand returns a project_archive command that archives a
+// project and all of its descendants.
+func NewProjectArchiveCommand(id string) Command {
+	return newCommand(CommandProjectArchive, idArgs{id})
+}
+
+// NewProjectUnarchiveCommand returns a project_unarchive command.
+func NewProjectUnarchiveCommand(id string) Command {
+	return newCommand(CommandProjectUnarchive, idArgs{id})
+}
+
+// NewProjectLeaveCommand returns a project_leave command that removes the
+// user from a shared project.
+func NewProjectLeaveCommand(projectID string) Command {
+	return newCommand(CommandProjectLeave, struct {
+		ProjectID string `json:"project_id"`
+	}{projectID})
+}
+
+// SectionAddArgs holds the arguments of the section_add command.
+type SectionAddArgs struct {
+	Name         string `json:"name"`
+	ProjectID    string `json:"project_id"`
+	SectionOrder int    `json:"section_order,omitempty"`
+}
+
+// NewSectionAddCommand returns a section_add command that creates a section.
+func NewSectionAddCommand(args SectionAddArgs) Command {
+	return newAddCommand(CommandSectionAdd, args)
+}
+
+// SectionUpdateArgs holds the arguments of the section_update command. Only
+// the fields that are not nil are updated.
+type SectionUpdateArgs struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name,omitempty"`
+	IsCollapsed *bool   `json:"is_collapsed,omitempty"`
+}
+
+// NewSectionUpdateCommand returns a section_update command that updates a
+// section.
+func NewSectionUpdateCommand(args SectionUpdateArgs) Command {
+	return newCommand(CommandSectionUpdate, args)
+}
+
+// NewSectionMoveCommand returns a section_move command that moves a section,
+// with its tasks, to another project.
+func NewSectionMoveCommand(id string, projectID string) Command {
+	return newCommand(CommandSectionMove, struct {
+		ID        string `json:"id"`
+		ProjectID string `json:"project_id"`
+	}{id, projectID})
+}
+
+// SectionOrder sets the position of a section within its project.
+type SectionOrder struct {
+	ID           string `json:"id"`
+	SectionOrder int    `json:"section_order"`
+}
+
+// NewSectionReorderCommand returns a section_reorder command that updates the
+// section_order of the given sections.
+func NewSectionReorderCommand(sections []SectionOrder) Command {
+	return newCommand(CommandSectionReorder, struct {
+		Sections []SectionOrder `json:"sections"`
+	}{sections})
+}
+
+// NewSectionDeleteCommand returns a section_delete command that deletes a
+// section and all of its tasks.
+func NewSectionDeleteCommand(id string) Command {
+	return newCommand(CommandSectionDelete, idArgs{id})
+}
+
+// NewSectionArchiveCommand returns a section_archive command.
+func NewSectionArchiveCommand(id string) Command {
+	return newCommand(CommandSectionArchive, idArgs{id})
+}
+
+// NewSectionUnarchiveCommand returns a section_unarchive command.
+func NewSectionUnarchiveCommand(id string) Command {
+	return newCommand(CommandSectionUnarchive, idArgs{id})
+}
+
+// LabelAddArgs holds the arguments of the label_add command.
+type LabelAddArgs struct {
+	Name       string `json:"name"`
+	Color      string `json:"color,omitempty"`
+	ItemOrder  int    `json:"item_order,omitempty"`
+	IsFavorite bool   `json:"is_favorite,omitempty"`
+}
+
+// NewLabelAddCommand returns a label_add command that creates a personal
+// label.
+func NewLabelAddCommand(args LabelAddArgs) Command {
+	return newAddCommand(CommandLabelAdd, args)
+}
+
+// LabelUpdateArgs holds the arguments of the label_update command. Only the
+// fields that are not nil are updated.
+type LabelUpdateArgs struct {
+	ID         string  `json:"id"`
+	Name       *string `json:"name,omitempty"`
+	Color      *string `json:"color,omitempty"`
+	ItemOrder  *int    `json:"item_order,omitempty"`
+	IsFavorite *bool   `json:"is_favorite,omitempty"`
+}
+
+// NewLabelUpdateCommand returns a label_update command that updates a
+// personal label.
+func NewLabelUpdateCommand(args LabelUpdateArgs) Command {
+	return newCommand(CommandLabelUpdate, args)
+}
+
+// LabelDeleteArgs holds the arguments of the label_delete command. Cascade is
+// either "none", the default, or "all" to also remove the label from tasks.
+type LabelDeleteArgs struct {
+	ID      string `json:"id"`
+	Cascade string `json:"cascade,omitempty"`
+}
+
+// NewLabelDeleteCommand returns a label_delete command that deletes a
+// personal label.
+func NewLabelDeleteCommand(args LabelDeleteArgs) Command {
+	return newCommand(CommandLabelDelete, args)
+}
+
+// NewLabelRenameCommand returns a label_rename command that renames a shared
+// label on all tasks.
+func NewLabelRenameCommand(oldName string, newName string) Command {
+	return newCommand(CommandLabelRename, struct {
+		NameOld string `json:"name_old"`
+		NameNew string `json:"name_new"`
+	}{oldName, newName})
+}
+
+// NewLabelDeleteOccurrencesCommand returns a label_delete_occurrences command
+// that removes a shared label from all tasks.
+func NewLabelDeleteOccurrencesCommand(name string) Command {
+	return newCommand(CommandLabelDeleteOccurrences, struct {
+		Name string `json:"name"`
+	}{name})
+}
+
+// NewLabelUpdateOrdersCommand returns a label_update_orders command.
+// idOrderMapping maps label IDs to their item_order.
+func NewLabelUpdateOrdersCommand(idOrderMapping map[string]int) Command {
+	return newCommand(CommandLabelUpdateOrders, struct {
+		IDOrderMapping map[string]int `json:"id_order_mapping"`
+	}{idOrderMapping})
+}
+
+// NoteAddArgs holds the arguments of the note_add command.
+type NoteAddArgs struct {
+	ItemID         string         `json:"item_id"`
+	Content        string         `json:"content"`
+	FileAttachment map[string]any `json:"file_attachment,omitempty"`
+	UIDsToNotify   []string       `json:"uids_to_notify,omitempty"`
+}
+
+// NewNoteAddCommand returns a note_add command that adds a comment to a task.
+func NewNoteAddCommand(args NoteAddArgs) Command {
+	return newAddCommand(CommandNoteAdd, args)
+}
+
+// NoteUpdateArgs holds the arguments of the note_update and
+// project_note_update commands.
+type NoteUpdateArgs struct {
+	ID             string         `json:"id"`
+	Content        string         `json:"content"`
+	FileAttachment map[string]any `json:"file_attachment,omitempty"`
+}
+
+// NewNoteUpdateCommand returns a note_update command that updates a task
+// comment.
+func NewNoteUpdateCommand(args NoteUpdateArgs) Command {
+	return newCommand(CommandNoteUpdate, args)
+}
+
+// NewNoteDeleteCommand returns a note_delete command that deletes a task
+// comment.
+func NewNoteDeleteCommand(id string) Command {
+	return newCommand(CommandNoteDelete, idArgs{id})
+}
+
+// ProjectNoteAddArgs holds the arguments of the project_note_add command.
+type ProjectNoteAddArgs struct {
+	ProjectID      string         `json:"project_id"`
+	Content        string         `json:"content"`
+	FileAttachment map[string]any `json:"file_attachment,omitempty"`
+	UIDsToNotify   []string       `json:"uids_to_notify,omitempty"`
+}
+
+// NewProjectNoteAddCommand returns a project_note_add command that adds a
+// comment to a project.
+func NewProjectNoteAddCommand(args ProjectNoteAddArgs) Command {
+	return newAddCommand(CommandProjectNoteAdd, args)
+}
+
+// NewProjectNoteUpdateCommand returns a project_note_update command that
+// updates a project comment.
+func NewProjectNoteUpdateCommand(args NoteUpdateArgs) Command {
+	return newCommand(CommandProjectNoteUpdate, args)
+}
+
+// NewProjectNoteDeleteCommand returns a project_note_delete command that
+// deletes a project comment.
+func NewProjectNoteDeleteCommand(id string) Command {
+	return newCommand(CommandProjectNoteDelete, idArgs{id})
+}
+
+// FilterAddArgs holds the arguments of the filter_add command.
+type FilterAddArgs struct {
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Color      string `json:"color,omitempty"`
+	ItemOrder  int    `json:"item_order,omitempty"`
+	IsFavorite bool   `json:"is_favorite,omitempty"`
+}
+
+// NewFilterAddCommand returns a filter_add command that creates a filter.
+func NewFilterAddCommand(args FilterAddArgs) Command {
+	return newAddCommand(CommandFilterAdd, args)
+}
+
+// FilterUpdateArgs holds the arguments of the filter_update command. Only the
+// fields that are not nil are updated.
+type FilterUpdateArgs struct {
+	ID         string  `json:"id"`
+	Name       *string `json:"name,omitempty"`
+	Query      *string `json:"query,omitempty"`
+	Color      *string `json:"color,omitempty"`
+	ItemOrder  *int    `json:"item_order,omitempty"`
+	IsFavorite *bool   `json:"is_favorite,omitempty"`
+}
+
+// NewFilterUpdateCommand returns a filter_update command that updates a
+// filter.
+func NewFilterUpdateCommand(args FilterUpdateArgs) Command {
+	return newCommand(CommandFilterUpdate, args)
+}
+
+// NewFilterDeleteCommand returns a filter_delete command.
+func NewFilterDeleteCommand(id string) Command {
+	return newCommand(CommandFilterDelete, idArgs{id})
+}
+
+// NewFilterUpdateOrdersCommand returns a filter_update_orders command.
+// idOrderMapping maps filter IDs to their item_order.
+func NewFilterUpdateOrdersCommand(idOrderMapping map[string]int) Command {
+	return newCommand(CommandFilterUpdateOrders, struct {
+		IDOrderMapping map[string]int `json:"id_order_mapping"`
+	}{idOrderMapping})
+}
+
+// ReminderAddArgs holds the arguments of the reminder_add command. Relative
+// reminders use MinuteOffset, absolute reminders use Due and location
+// reminders use the Name, LocLat, LocLong, LocTrigger and Radius fields. The
+// NewRelativeReminder, NewAbsoluteReminder and NewLocationReminder functions
+// return the arguments for each type.
+type ReminderAddArgs struct {
+	ItemID       string       `json:"item_id"`
+	NotifyUID    string       `json:"notify_uid,omitempty"`
+	Type         ReminderType `json:"type,omitempty"`
+	Due          *DueArgs     `json:"due,omitempty"`
+	MinuteOffset *int         `json:"minute_offset,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	LocLat       string       `json:"loc_lat,omitempty"`
+	LocLong      string       `json:"loc_long,omitempty"`
+	LocTrigger   string       `json:"loc_trigger,omitempty"` // "on_enter" or "on_leave"
+	Radius       int          `json:"radius,omitempty"`      // In meters
+}
+
+// NewReminderAddCommand returns a reminder_add command that creates a
+// reminder.
+func NewReminderAddCommand(args ReminderAddArgs) Command {
+	return newAddCommand(CommandReminderAdd, args)
+}
+
+// ReminderUpdateArgs holds the arguments of the reminder_update command. Only
+// the fields that are not nil are updated.
+type ReminderUpdateArgs struct {
+	ID           string        `json:"id"`
+	NotifyUID    *string       `json:"notify_uid,omitempty"`
+	Type         *ReminderType `json:"type,omitempty"`
+	Due          *DueArgs      `json:"due,omitempty"`
+	MinuteOffset *int          `json:"minute_offset,omitempty"`
+	Name         *string       `json:"name,omitempty"`
+	LocLat       *string       `json:"loc_lat,omitempty"`
+	LocLong      *string       `json:"loc_long,omitempty"`
+	LocTrigger   *string       `json:"loc_trigger,omitempty"`
+	Radius       *int          `json:"radius,omitempty"`
+}
+
+// NewReminderUpdateCommand returns a reminder_update command that updates a
+// reminder.
+func NewReminderUpdateCommand(args ReminderUpdateArgs) Command {
+	return newCommand(CommandReminderUpdate, args)
+}
+
+// NewReminderDeleteCommand returns a reminder_delete command.
+func NewReminderDeleteCommand(id string) Command {
+	return newCommand(CommandReminderDelete, idArgs{id})
+}
+
+// ShareProjectArgs holds the arguments of the share_project command. Role is
+// only used for workspace projects.
+type ShareProjectArgs struct {
+	ProjectID string `json:"project_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
+}
+
+// NewShareProjectCommand returns a share_project command that invites a user
+// to a project.
+func NewShareProjectCommand(args ShareProjectArgs) Command {
+	return newCommand(CommandShareProject, args)
+}
+
+// NewDeleteCollaboratorCommand returns a delete_collaborator command that
+// removes a user from a shared project.
+func NewDeleteCollaboratorCommand(projectID string, email string) Command {
+	return newCommand(CommandDeleteCollaborator, struct {
+		ProjectID string `json:"project_id"`
+		Email     string `json:"email"`
+	}{projectID, email})
+}
+
+// invitationArgs is used by the commands that answer an invitation.
+type invitationArgs struct {
+	InvitationID     string `json:"invitation_id"`
+	InvitationSecret string `json:"invitation_secret"`
+}
+
+// NewAcceptInvitationCommand returns an accept_invitation command.
+func NewAcceptInvitationCommand(invitationID string, secret string) Command {
+	return newCommand(
+		CommandAcceptInvitation,
+		invitationArgs{invitationID, secret},
+	)
+}
+
+// NewRejectInvitationCommand returns a reject_invitation command.
+func NewRejectInvitationCommand(invitationID string, secret string) Command {
+	return newCommand(
+		CommandRejectInvitation,
+		invitationArgs{invitationID, secret},
+	)
+}
+
+// NewDeleteInvitationCommand returns a delete_invitation command that cancels
+// an invitation sent by the user.
+func NewDeleteInvitationCommand(invitationID string) Command {
+	return newCommand(CommandDeleteInvitation, struct {
+		InvitationID string `json:"invitation_id"`
+	}{invitationID})
+}
+
+// UserUpdateArgs holds the arguments of the user_update command. Only the
+// fields that are not nil are updated. CurrentPassword is required when
+// changing Email or Password.
+type UserUpdateArgs struct {
+	CurrentPassword *string `json:"current_password,omitempty"`
+	Email           *string `json:"email,omitempty"`
+	FullName        *string `json:"full_name,omitempty"`
+	Password        *string `json:"password,omitempty"`
+	Timezone        *string `json:"timezone,omitempty"`
+	StartPage       *string `json:"start_page,omitempty"`
+	StartDay        *int    `json:"start_day,omitempty"`
+	NextWeek        *int    `json:"next_week,omitempty"`
+	TimeFormat      *int    `json:"time_format,omitempty"`
+	DateFormat      *int    `json:"date_format,omitempty"`
+	SortOrder       *int    `json:"sort_order,omitempty"`
+	AutoReminder    *int    `json:"auto_reminder,omitempty"`
+	WeekendStartDay *int    `json:"weekend_start_day,omitempty"`
+	Theme           *int    `json:"theme,omitempty"`
+}
+
+// NewUserUpdateCommand returns a user_update command that updates the user's
+// settings.
+func NewUserUpdateCommand(args UserUpdateArgs) Command {
+	return newCommand(CommandUserUpdate, args)
+}
+
+// UserUpdateGoalsArgs holds the arguments of the update_goals command. Only
+// the fields that are not nil are updated.
+type UserUpdateGoalsArgs struct {
+	DailyGoal     *int  `json:"daily_goal,omitempty"`
+	WeeklyGoal    *int  `json:"weekly_goal,omitempty"`
+	IgnoreDays    []int `json:"ignore_days,omitempty"`
+	VacationMode  *int  `json:"vacation_mode,omitempty"`
+	KarmaDisabled *int  `json:"karma_disabled,omitempty"`
+}
+
+// NewUserUpdateGoalsCommand returns an update_goals command that updates the
+// user's karma goals.
+func NewUserUpdateGoalsCommand(args UserUpdateGoalsArgs) Command {
+	return newCommand(CommandUserUpdateGoals, args)
+}
+
+// WorkspaceAddArgs holds the arguments of the workspace_add command.
+type WorkspaceAddArgs struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description,omitempty"`
+	IsLinkSharingEnabled *bool  `json:"is_link_sharing_enabled,omitempty"`
+	IsGuestAllowed       *bool  `json:"is_guest_allowed,omitempty"`
+	DomainName           string `json:"domain_name,omitempty"`
+	DomainDiscovery      *bool  `json:"domain_discovery,omitempty"`
+	RestrictEmailDomains *bool  `json:"restrict_email_domains,omitempty"`
+}
+
+// NewWorkspaceAddCommand returns a workspace_add command that creates a
+// workspace.
+func NewWorkspaceAddCommand(args WorkspaceAddArgs) Command {
+	return newAddCommand(CommandWorkspaceAdd, args)
+}
+
+// WorkspaceUpdateArgs holds the arguments of the workspace_update command.
+// Only the fields that are not nil are updated.
+type WorkspaceUpdateArgs struct {
+	ID                   string  `json:"id"`
+	Name                 *string `json:"name,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	IsLinkSharingEnabled *bool   `json:"is_link_sharing_enabled,omitempty"`
+	IsGuestAllowed       *bool   `json:"is_guest_allowed,omitempty"`
+	DomainName           *string `json:"domain_name,omitempty"`
+	DomainDiscovery      *bool   `json:"domain_discovery,omitempty"`
+	RestrictEmailDomains *bool   `json:"restrict_email_domains,omitempty"`
+	IsCollapsed          *bool   `json:"is_collapsed,omitempty"`
+}
+
+// NewWorkspaceUpdateCommand returns a workspace_update command that updates
+// a workspace.
+func NewWorkspaceUpdateCommand(args WorkspaceUpdateArgs) Command {
+	return newCommand(CommandWorkspaceUpdate, args)
+}
+
+// NewWorkspaceDeleteCommand returns a workspace_delete command.
+func NewWorkspaceDeleteCommand(id string) Command {
+	return newCommand(CommandWorkspaceDelete, idArgs{id})
+}
+
+// NewWorkspaceLeaveCommand returns a workspace_leave command that removes the
+// user from a workspace.
+func NewWorkspaceLeaveCommand(id string) Command {
+	return newCommand(CommandWorkspaceLeave, idArgs{id})
+}
+
+// WorkspaceInviteArgs holds the arguments of the workspace_invite command.
+// Role is one of "ADMIN", "MEMBER" or "GUEST".
+type WorkspaceInviteArgs struct {
+	WorkspaceID string   `json:"workspace_id"`
+	EmailList   []string `json:"email_list"`
+	Role        string   `json:"role,omitempty"`
+}
+
+// NewWorkspaceInviteCommand returns a workspace_invite command that invites
+// users to a workspace by email.
+func NewWorkspaceInviteCommand(args WorkspaceInviteArgs) Command {
+	return newCommand(CommandWorkspaceInvite, args)
+}
+
+// NewWorkspaceUpdateUserCommand returns a workspace_update_user command that
+// changes the role of a workspace member.
+func NewWorkspaceUpdateUserCommand(
+	workspaceID string,
+	userEmail string,
+	role string,
+) Command {
+	return newCommand(CommandWorkspaceUpdateUser, struct {
+		WorkspaceID string `json:"workspace_id"`
+		UserEmail   string `json:"user_email"`
+		Role        string `json:"role"`
+	}{workspaceID, userEmail, role})
+}
+
+// NewWorkspaceDeleteUserCommand returns a workspace_delete_user command that
+// removes a member from a workspace.
+func NewWorkspaceDeleteUserCommand(
+	workspaceID string,
+	userEmail string,
+) Command {
+	return newCommand(CommandWorkspaceDeleteUser, struct {
+		WorkspaceID string `json:"workspace_id"`
+		UserEmail   string `json:"user_email"`
+	}{workspaceID, userEmail})
+}
+
+// nullableString returns nil for an empty string, so that it is sent as null.
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
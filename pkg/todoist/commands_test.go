@@ -0,0 +1,167 @@
+package todoist
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommandBuilders(t *testing.T) {
+	content := "Buy bread"
+	priority := 4
+	tests := []struct {
+		name     string
+		command  Command
+		wantType string
+		wantArgs string
+		isAdd    bool
+	}{
+		{
+			name: "item add",
+			command: NewItemAddCommand(ItemAddArgs{
+				Content:   "Buy milk",
+				ProjectID: "p1",
+				Due:       &DueArgs{String: "tomorrow", Lang: "en"},
+				Labels:    []string{"home"},
+			}),
+			wantType: CommandItemAdd,
+			wantArgs: `{"content":"Buy milk","due":{"lang":"en",` +
+				`"string":"tomorrow"},"labels":["home"],"project_id":"p1"}`,
+			isAdd: true,
+		},
+		{
+			name: "item update",
+			command: NewItemUpdateCommand(ItemUpdateArgs{
+				ID:       "t1",
+				Content:  &content,
+				Priority: &priority,
+			}),
+			wantType: CommandItemUpdate,
+			wantArgs: `{"content":"Buy bread","id":"t1","priority":4}`,
+		},
+		{
+			name:     "item close",
+			command:  NewItemCloseCommand("t1"),
+			wantType: CommandItemClose,
+			wantArgs: `{"id":"t1"}`,
+		},
+		{
+			name: "item reorder",
+			command: NewItemReorderCommand([]ItemOrder{
+				{ID: "t1", ChildOrder: 1},
+				{ID: "t2", ChildOrder: 2},
+			}),
+			wantType: CommandItemReorder,
+			wantArgs: `{"items":[{"child_order":1,"id":"t1"},` +
+				`{"child_order":2,"id":"t2"}]}`,
+		},
+		{
+			name:     "item day orders",
+			command:  NewItemUpdateDayOrdersCommand(map[string]int{"t1": 3}),
+			wantType: CommandItemUpdateDayOrders,
+			wantArgs: `{"ids_to_orders":{"t1":3}}`,
+		},
+		{
+			name:     "project add",
+			command:  NewProjectAddCommand(ProjectAddArgs{Name: "Work"}),
+			wantType: CommandProjectAdd,
+			wantArgs: `{"name":"Work"}`,
+			isAdd:    true,
+		},
+		{
+			name:     "project move to root",
+			command:  NewProjectMoveCommand("p1", ""),
+			wantType: CommandProjectMove,
+			wantArgs: `{"id":"p1","parent_id":null}`,
+		},
+		{
+			name: "section add",
+			command: NewSectionAddCommand(SectionAddArgs{
+				Name:      "Doing",
+				ProjectID: "p1",
+			}),
+			wantType: CommandSectionAdd,
+			wantArgs: `{"name":"Doing","project_id":"p1"}`,
+			isAdd:    true,
+		},
+		{
+			name:     "label rename",
+			command:  NewLabelRenameCommand("old", "new"),
+			wantType: CommandLabelRename,
+			wantArgs: `{"name_new":"new","name_old":"old"}`,
+		},
+		{
+			name:     "label update orders",
+			command:  NewLabelUpdateOrdersCommand(map[string]int{"l1": 2}),
+			wantType: CommandLabelUpdateOrders,
+			wantArgs: `{"id_order_mapping":{"l1":2}}`,
+		},
+		{
+			name:     "accept invitation",
+			command:  NewAcceptInvitationCommand("i1", "secret"),
+			wantType: CommandAcceptInvitation,
+			wantArgs: `{"invitation_id":"i1","invitation_secret":"secret"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.command.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", tt.command.Type, tt.wantType)
+			}
+			args, err := json.Marshal(tt.command.Args)
+			if err != nil {
+				t.Fatalf("failed to encode args: %v", err)
+			}
+			if string(args) != tt.wantArgs {
+				t.Errorf("args = %s, want %s", args, tt.wantArgs)
+			}
+			if tt.command.UUID == "" {
+				t.Error("UUID is empty")
+			}
+			if hasTempID := tt.command.TempID != ""; hasTempID != tt.isAdd {
+				t.Errorf("has temp ID = %t, want %t", hasTempID, tt.isAdd)
+			}
+		})
+	}
+}
+
+func TestCommandJSON(t *testing.T) {
+	command := NewLabelAddCommand(LabelAddArgs{Name: "home"}).
+		WithTempID("tmp-1")
+	command.UUID = "uuid-1"
+
+	data, err := json.Marshal(command)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"type":"label_add","args":{"name":"home"},` +
+		`"uuid":"uuid-1","temp_id":"tmp-1"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(NewLabelDeleteCommand(LabelDeleteArgs{ID: "l1"}))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, ok := fields["temp_id"]; ok {
+		t.Errorf("Marshal = %s, want no temp_id", data)
+	}
+}
+
+func TestCommandUUIDsAreUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		command := NewProjectAddCommand(ProjectAddArgs{Name: "Work"})
+		for _, id := range []string{command.UUID, command.TempID} {
+			if seen[id] {
+				t.Fatalf("ID %q was generated twice", id)
+			}
+			seen[id] = true
+		}
+	}
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // Comment represents a Todoist comment on a task or project.
 type Comment struct {
 	ID             string          `json:"id"`
+	ItemID         string          `json:"item_id,omitempty"`    // set for task comments
+	ProjectID      string          `json:"project_id,omitempty"` // set for project comments
 	PostedUID      *string         `json:"posted_uid"`
 	Content        string          `json:"content"`
 	FileAttachment *map[string]any `json:"file_attachment"`
 	UIDsToNotify   *[]string       `json:"uids_to_notify"`
 	IsDeleted      bool            `json:"is_deleted"`
-	PostedAt       string          `json:"posted_at"`
+	PostedAt       *Timestamp      `json:"posted_at"`
 	Reactions      *map[string]any `json:"reactions"`
 }
 
@@ -37,6 +40,16 @@ type CommentOptions struct {
 	UIDsToNotify *[]int          `json:"uids_to_notify,omitempty"`
 }
 
+// CommentUpdate holds the parameters for updating a comment. Only the fields
+// that are set are updated.
+type CommentUpdate struct {
+	Content Optional[string] `json:"content"`
+}
+
+func (u CommentUpdate) MarshalJSON() ([]byte, error) {
+	return marshalUpdate(u)
+}
+
 // GetComments returns a list of all comments for a given task_id or project_id
 // Exactly one of filters.TaskID or filters.ProjectID must be non-empty.
 func (c *Client) GetComments(
@@ -45,12 +58,14 @@ func (c *Client) GetComments(
 ) ([]Comment, *string, error) {
 	if filters.TaskID == "" && filters.ProjectID == "" {
 		return nil, nil, fmt.Errorf(
-			"either TaskID or ProjectID must be provided in filters",
+			"%w: either TaskID or ProjectID must be provided in filters",
+			ErrInvalidArgument,
 		)
 	}
 	if filters.TaskID != "" && filters.ProjectID != "" {
 		return nil, nil, fmt.Errorf(
-			"provide either TaskID or ProjectID, not both",
+			"%w: provide either TaskID or ProjectID, not both",
+			ErrInvalidArgument,
 		)
 	}
 
@@ -71,6 +86,22 @@ func (c *Client) GetComments(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllComments returns an iterator over all comments for a given task_id or
+// project_id, requesting further pages as needed.
+func (c *Client) AllComments(
+	ctx context.Context,
+	filters CommentFilters,
+) iter.Seq2[Comment, error] {
+	return paginate(
+		ctx,
+		filters.Cursor,
+		func(cursor string) ([]Comment, *string, error) {
+			filters.Cursor = cursor
+			return c.GetComments(ctx, filters)
+		},
+	)
+}
+
 // CreateComment creates a new comment on a task or project.
 // content is required. Exactly one of options.TaskID or options.ProjectID must
 // be non-empty.
@@ -80,15 +111,22 @@ func (c *Client) CreateComment(
 	options *CommentOptions,
 ) (*Comment, error) {
 	if content == "" {
-		return nil, fmt.Errorf("comment content cannot be empty")
+		return nil, fmt.Errorf(
+			"%w: comment content cannot be empty",
+			ErrInvalidArgument,
+		)
 	}
 	if options == nil || (options.TaskID == "" && options.ProjectID == "") {
 		return nil, fmt.Errorf(
-			"either TaskID or ProjectID must be provided in options",
+			"%w: either TaskID or ProjectID must be provided in options",
+			ErrInvalidArgument,
 		)
 	}
 	if options.TaskID != "" && options.ProjectID != "" {
-		return nil, fmt.Errorf("provide either TaskID or ProjectID, not both")
+		return nil, fmt.Errorf(
+			"%w: provide either TaskID or ProjectID, not both",
+			ErrInvalidArgument,
+		)
 	}
 
 	body := options
@@ -117,7 +155,7 @@ func (c *Client) GetComment(
 	commentID string,
 ) (*Comment, error) {
 	if commentID == "" {
-		return nil, fmt.Errorf("comment ID cannot be empty")
+		return nil, fmt.Errorf("%w: comment ID cannot be empty", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
@@ -140,21 +178,22 @@ func (c *Client) GetComment(
 	return &comment, nil
 }
 
-// UpdateComment updates an existing comment's content by its ID.
+// UpdateComment updates the fields of an existing comment that are set in
+// update.
 func (c *Client) UpdateComment(
 	ctx context.Context,
 	commentID string,
-	content string,
+	update *CommentUpdate,
 ) (*Comment, error) {
-	body := CommentOptions{
-		Content: content,
+	if update == nil {
+		return nil, fmt.Errorf("%w: update is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
 		ctx,
 		"POST",
 		fmt.Sprintf("/comments/%s", commentID),
-		body,
+		update,
 		nil,
 	)
 	if err != nil {
@@ -0,0 +1,288 @@
+package todoist
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Date layouts used by the API for due dates and deadlines.
+const (
+	dateLayout         = "2006-01-02"
+	floatingTimeLayout = "2006-01-02T15:04:05"
+)
+
+// Due is the due date of a task.
+//
+// Date holds either a full-day date (YYYY-MM-DD), a floating date time
+// (YYYY-MM-DDTHH:MM:SS) that is interpreted in the user's timezone, or a date
+// time fixed to a timezone, in UTC (YYYY-MM-DDTHH:MM:SSZ). Fixed dues also
+// set Timezone to the timezone they were created in.
+//
+// A decoded Due encodes to the same JSON object: fields that are not known to
+// this struct are kept, and known fields that were absent are only written
+// once they are set.
+type Due struct {
+	Date        string  `json:"date"`
+	Datetime    *string `json:"datetime,omitempty"` // Only sent by some endpoints
+	Timezone    *string `json:"timezone"`
+	String      string  `json:"string"`
+	Lang        string  `json:"lang"`
+	IsRecurring bool    `json:"is_recurring"`
+
+	raw jsonObject
+}
+
+func (d *Due) UnmarshalJSON(data []byte) error {
+	type due Due
+	return d.raw.unmarshal(data, (*due)(d))
+}
+
+func (d Due) MarshalJSON() ([]byte, error) {
+	type due Due
+	return d.raw.marshal(due(d))
+}
+
+// value returns the date or date time of the due, preferring Datetime when it
+// is set.
+func (d *Due) value() string {
+	if d.Datetime != nil && *d.Datetime != "" {
+		return *d.Datetime
+	}
+	return d.Date
+}
+
+// HasTime reports whether the due has a time of day, as opposed to being
+// due for the whole day.
+func (d *Due) HasTime() bool {
+	return strings.Contains(d.value(), "T")
+}
+
+// IsFloating reports whether the due has a time of day that is not fixed to
+// a timezone. Floating dues happen at the same local time in whatever
+// timezone the user is in.
+func (d *Due) IsFloating() bool {
+	return d.HasTime() && !hasZone(d.value())
+}
+
+// IsFixed reports whether the due is fixed to a point in time, regardless of
+// the user's timezone.
+func (d *Due) IsFixed() bool {
+	return d.HasTime() && hasZone(d.value())
+}
+
+// Location returns the timezone of a fixed due, or nil if the due has no
+// timezone.
+func (d *Due) Location() (*time.Location, error) {
+	if d.Timezone == nil || *d.Timezone == "" {
+		return nil, nil
+	}
+	return time.LoadLocation(*d.Timezone)
+}
+
+// Time resolves the due in the user's timezone loc. Full-day dues resolve to
+// midnight and floating dues to their local time in loc, while fixed dues
+// resolve to their point in time, expressed in loc. A nil loc uses
+// time.Local.
+func (d *Due) Time(loc *time.Location) (time.Time, error) {
+	return parseDueValue(d.value(), loc)
+}
+
+// Deadline is the deadline of a task, which is always a full-day date.
+type Deadline struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Lang string `json:"lang,omitempty"`
+
+	raw jsonObject
+}
+
+func (d *Deadline) UnmarshalJSON(data []byte) error {
+	type deadline Deadline
+	return d.raw.unmarshal(data, (*deadline)(d))
+}
+
+func (d Deadline) MarshalJSON() ([]byte, error) {
+	type deadline Deadline
+	return d.raw.marshal(deadline(d))
+}
+
+// Time returns midnight of the deadline date in loc. A nil loc uses
+// time.Local.
+func (d *Deadline) Time(loc *time.Location) (time.Time, error) {
+	return parseDueValue(d.Date, loc)
+}
+
+// DurationUnit is the unit of a task Duration.
+type DurationUnit string
+
+const (
+	DurationMinute DurationUnit = "minute"
+	DurationDay    DurationUnit = "day"
+)
+
+// Duration is the time a task is expected to take.
+type Duration struct {
+	Amount int          `json:"amount"`
+	Unit   DurationUnit `json:"unit"`
+
+	raw jsonObject
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	type duration Duration
+	return d.raw.unmarshal(data, (*duration)(d))
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	type duration Duration
+	return d.raw.marshal(duration(d))
+}
+
+// TimeDuration converts the duration to a time.Duration, counting a day as
+// 24 hours.
+func (d Duration) TimeDuration() (time.Duration, error) {
+	switch d.Unit {
+	case DurationMinute:
+		return time.Duration(d.Amount) * time.Minute, nil
+	case DurationDay:
+		return time.Duration(d.Amount) * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("unknown duration unit %q", d.Unit)
+}
+
+// CompareDue compares two dues by the time they resolve to in loc. Full-day
+// dues resolve to the start of their day. A nil due, or one that cannot be
+// parsed, sorts after every other due.
+func CompareDue(a, b *Due, loc *time.Location) int {
+	timeA, okA := dueTime(a, loc)
+	timeB, okB := dueTime(b, loc)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+	return timeA.Compare(timeB)
+}
+
+// SortTasksByDue sorts tasks by due date in loc, as CompareDue does. Tasks
+// due at the same time are sorted by priority, highest first, and then by
+// child order.
+func SortTasksByDue(tasks []Task, loc *time.Location) {
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		return cmp.Or(
+			CompareDue(a.Due, b.Due, loc),
+			cmp.Compare(b.Priority, a.Priority),
+			cmp.Compare(a.ChildOrder, b.ChildOrder),
+		)
+	})
+}
+
+// dueTime resolves d in loc and reports whether it could be resolved.
+func dueTime(d *Due, loc *time.Location) (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+	t, err := d.Time(loc)
+	return t, err == nil
+}
+
+// parseDueValue parses a date or date time as sent by the API in loc.
+func parseDueValue(value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	switch {
+	case !strings.Contains(value, "T"):
+		return time.ParseInLocation(dateLayout, value, loc)
+	case hasZone(value):
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.In(loc), nil
+	default:
+		return time.ParseInLocation(floatingTimeLayout, value, loc)
+	}
+}
+
+// hasZone reports whether a date time ends with a UTC designator or offset.
+func hasZone(value string) bool {
+	i := strings.Index(value, "T")
+	if i < 0 {
+		return false
+	}
+	clock := value[i:]
+	return strings.HasSuffix(clock, "Z") || strings.ContainsAny(clock, "+-")
+}
+
+// jsonObject remembers the keys of a decoded JSON object and the fields that
+// did not match the struct it was decoded into, so that the struct encodes to
+// the same object again.
+type jsonObject struct {
+	keys  map[string]bool
+	extra map[string]json.RawMessage
+}
+
+// unmarshal decodes data into v and remembers the keys of the object.
+func (o *jsonObject) unmarshal(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	o.keys = make(map[string]bool, len(fields))
+	for key := range fields {
+		o.keys[key] = true
+	}
+	for key := range jsonFields(v) {
+		delete(fields, key)
+	}
+	o.extra = nil
+	if len(fields) > 0 {
+		o.extra = fields
+	}
+	return nil
+}
+
+// marshal encodes v, leaving out the fields that were not in the decoded
+// object and still have their zero value, and adds the unknown fields.
+func (o jsonObject) marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		if !o.keys[key] && isZeroJSON(value) {
+			delete(fields, key)
+		}
+	}
+	for key, value := range o.extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// isZeroJSON reports whether value is the encoding of a zero value.
+func isZeroJSON(value json.RawMessage) bool {
+	switch string(value) {
+	case "null", `""`, "false", "0":
+		return true
+	}
+	return false
+}
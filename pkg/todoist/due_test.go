@@ -0,0 +1,72 @@
+package todoist
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDueRoundTrip(t *testing.T) {
+	tests := []string{
+		`{"date":"2024-03-10"}`,
+		`{"date":"2024-03-10","string":"every day","lang":"en","is_recurring":true,"timezone":null}`,
+		`{"date":"2024-03-10T12:00:00Z","timezone":"Europe/Paris","string":""}`,
+		`{"date":"2024-03-10","datetime":null,"unknown":{"a":[1,2]}}`,
+	}
+	for _, input := range tests {
+		var due Due
+		if err := json.Unmarshal([]byte(input), &due); err != nil {
+			t.Fatalf("failed to decode %s: %v", input, err)
+		}
+		assertSameJSON(t, due, input)
+	}
+}
+
+func TestDueSetFieldIsWritten(t *testing.T) {
+	var due Due
+	if err := json.Unmarshal([]byte(`{"date":"2024-03-10"}`), &due); err != nil {
+		t.Fatal(err)
+	}
+	due.String = "Mar 10"
+	due.IsRecurring = true
+	assertSameJSON(
+		t,
+		due,
+		`{"date":"2024-03-10","string":"Mar 10","is_recurring":true}`,
+	)
+}
+
+func TestDeadlineAndDurationRoundTrip(t *testing.T) {
+	var deadline Deadline
+	input := `{"date":"2024-03-10","lang":"en","extra":1}`
+	if err := json.Unmarshal([]byte(input), &deadline); err != nil {
+		t.Fatal(err)
+	}
+	assertSameJSON(t, deadline, input)
+
+	var duration Duration
+	input = `{"amount":0,"unit":"minute"}`
+	if err := json.Unmarshal([]byte(input), &duration); err != nil {
+		t.Fatal(err)
+	}
+	assertSameJSON(t, duration, input)
+}
+
+// assertSameJSON checks that v encodes to the same JSON value as want.
+func assertSameJSON(t *testing.T, v any, want string) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	var got, expected any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("encoded %s, want %s", data, want)
+	}
+}
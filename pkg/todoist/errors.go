@@ -0,0 +1,130 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors that can be matched with errors.Is against the errors
+// returned by the client. An *APIError matches the sentinel for its status
+// code, and arguments rejected before a request is sent wrap
+// ErrInvalidArgument.
+//
+// Example:
+//
+//	task, err := client.GetTask(ctx, taskID)
+//	if errors.Is(err, todoist.ErrNotFound) {
+//	 // the task does not exist or was deleted
+//	}
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrPlanLimitExceeded is returned by client-side checks when an action
+	// would exceed a limit of the user's plan.
+	ErrPlanLimitExceeded = errors.New("plan limit exceeded")
+)
+
+// APIError is returned when the Todoist API responds with a status of 400 or
+// above. Use errors.As to inspect it.
+//
+// The Todoist error fields are only set when the response body contains the
+// JSON error object the API returns for most failures.
+type APIError struct {
+	StatusCode   int    // Numeric HTTP status code, e.g. 404
+	Status       string // HTTP status line, e.g. "404 Not Found"
+	Method       string // Method of the failed request
+	URL          string // URL of the failed request
+	RequestID    string // Value of the X-Request-Id header, if any
+	ResponseBody []byte
+
+	Message    string         // Human readable error message
+	ErrorCode  int            // Todoist error code
+	ErrorTag   string         // Todoist error tag, e.g. "INVALID_ARGUMENT_VALUE"
+	ErrorExtra map[string]any // Additional details about the error
+}
+
+// apiErrorBody is the JSON object returned by the API for failed requests.
+type apiErrorBody struct {
+	Error      string         `json:"error"`
+	ErrorCode  int            `json:"error_code"`
+	ErrorTag   string         `json:"error_tag"`
+	ErrorExtra map[string]any `json:"error_extra"`
+}
+
+// newAPIError builds an *APIError from a failed response and closes its body.
+func newAPIError(req *http.Request, res *http.Response) *APIError {
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	apiErr := &APIError{
+		StatusCode:   res.StatusCode,
+		Status:       res.Status,
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		RequestID:    res.Header.Get("X-Request-Id"),
+		ResponseBody: body,
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = req.Header.Get("X-Request-Id")
+	}
+
+	var errBody apiErrorBody
+	if json.Unmarshal(body, &errBody) == nil {
+		apiErr.Message = errBody.Error
+		apiErr.ErrorCode = errBody.ErrorCode
+		apiErr.ErrorTag = errBody.ErrorTag
+		apiErr.ErrorExtra = errBody.ErrorExtra
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = string(e.ResponseBody)
+		// Limit to first 100 characters for readability
+		if runes := []rune(message); len(runes) > 100 {
+			message = string(runes[:100]) + "..."
+		}
+	}
+
+	s := fmt.Sprintf("API error: %s %s: status %s", e.Method, e.URL, e.Status)
+	if message != "" {
+		s += ": " + message
+	}
+	if e.ErrorTag != "" {
+		s += fmt.Sprintf(" (%s, code %d)", e.ErrorTag, e.ErrorCode)
+	}
+	return s
+}
+
+// Is reports whether the error matches one of the sentinel errors based on
+// its status code.
+func (e *APIError) Is(target error) bool {
+	return target != nil && target == sentinelForStatus(e.StatusCode)
+}
+
+// sentinelForStatus returns the sentinel error for an HTTP status code, or
+// nil if there is none.
+func sentinelForStatus(statusCode int) error {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidArgument
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
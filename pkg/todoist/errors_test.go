@@ -0,0 +1,151 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Task not found","error_code":478,` +
+			`"error_tag":"NOT_FOUND","error_extra":{"retry_after":0}}`))
+	})
+
+	_, err := c.GetTask(context.Background(), "t1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false for %v", err)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("errors.Is(err, ErrUnauthorized) = true, want false")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Method != "GET" {
+		t.Errorf("status %d method %s, want 404 GET", apiErr.StatusCode, apiErr.Method)
+	}
+	if !strings.HasSuffix(apiErr.URL, "/tasks/t1") {
+		t.Errorf("URL = %q, want it to end with /tasks/t1", apiErr.URL)
+	}
+	if apiErr.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want req-1", apiErr.RequestID)
+	}
+	if apiErr.Message != "Task not found" || apiErr.ErrorCode != 478 ||
+		apiErr.ErrorTag != "NOT_FOUND" || apiErr.ErrorExtra == nil {
+		t.Errorf("error fields = %+v, want the decoded body", apiErr)
+	}
+	if !strings.Contains(apiErr.Error(), "Task not found (NOT_FOUND, code 478)") {
+		t.Errorf("Error() = %q, want the message, tag and code", apiErr.Error())
+	}
+}
+
+func TestAPIErrorWithoutJSONBody(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+	})
+
+	_, err := c.GetTask(context.Background(), "t1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an *APIError", err)
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("errors.Is(err, ErrForbidden) = false for %v", err)
+	}
+	if string(apiErr.ResponseBody) != "Forbidden" || apiErr.Message != "" {
+		t.Errorf(
+			"body %q message %q, want the raw body only",
+			apiErr.ResponseBody,
+			apiErr.Message,
+		)
+	}
+	if !strings.HasSuffix(apiErr.Error(), ": Forbidden") {
+		t.Errorf("Error() = %q, want it to end with the body", apiErr.Error())
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{http.StatusBadRequest, ErrInvalidArgument},
+		{http.StatusUnprocessableEntity, ErrInvalidArgument},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusGone, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, nil},
+	}
+	sentinels := []error{
+		ErrInvalidArgument,
+		ErrUnauthorized,
+		ErrForbidden,
+		ErrNotFound,
+		ErrRateLimited,
+		ErrPlanLimitExceeded,
+	}
+
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.statusCode})
+		commandErr := error(&CommandError{HTTPCode: tt.statusCode})
+		for _, sentinel := range sentinels {
+			want := sentinel == tt.want
+			if got := errors.Is(err, sentinel); got != want {
+				t.Errorf(
+					"APIError %d: errors.Is(%v) = %t, want %t",
+					tt.statusCode,
+					sentinel,
+					got,
+					want,
+				)
+			}
+			if got := errors.Is(commandErr, sentinel); got != want {
+				t.Errorf(
+					"CommandError %d: errors.Is(%v) = %t, want %t",
+					tt.statusCode,
+					sentinel,
+					got,
+					want,
+				)
+			}
+		}
+	}
+}
+
+func TestCommandErrorFromSync(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []Command
+		err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands)
+		if err != nil {
+			t.Errorf("failed to decode commands: %v", err)
+			return
+		}
+		w.Write([]byte(`{"sync_token":"t","sync_status":{"` +
+			commands[0].UUID + `":{"error":"Item not found","error_code":22,` +
+			`"error_tag":"ITEM_NOT_FOUND","http_code":404}}}`))
+	})
+
+	_, err := c.Sync.execute(context.Background(), NewItemCloseCommand("t1"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false for %v", err)
+	}
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) {
+		t.Fatalf("error = %v, want a *CommandError", err)
+	}
+	if commandErr.ErrorTag != "ITEM_NOT_FOUND" || commandErr.ErrorCode != 22 {
+		t.Errorf("error fields = %+v, want the decoded status", commandErr)
+	}
+}
@@ -0,0 +1,404 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EventType is the kind of change described by an Event.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// ResourceType is the type of resource an Event is about. The values match
+// the names used by the Sync API.
+type ResourceType string
+
+const (
+	ResourceProject           ResourceType = "project"
+	ResourceItem              ResourceType = "item"
+	ResourceSection           ResourceType = "section"
+	ResourceLabel             ResourceType = "label"
+	ResourceNote              ResourceType = "note"
+	ResourceProjectNote       ResourceType = "project_note"
+	ResourceFilter            ResourceType = "filter"
+	ResourceReminder          ResourceType = "reminder"
+	ResourceCollaborator      ResourceType = "collaborator"
+	ResourceCollaboratorState ResourceType = "collaborator_state"
+	ResourceWorkspace         ResourceType = "workspace"
+	ResourceWorkspaceUser     ResourceType = "workspace_user"
+)
+
+// FieldChange is a single field that changed in an update. Field is the JSON
+// name of the field, and Old and New hold its JSON decoded values.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// Event describes a change made to a Store by a sync response.
+//
+// Old and New hold the resource before and after the change, as a value of
+// the resource struct, e.g. Task for ResourceItem. Old is nil for created
+// resources and New is nil for deleted resources. Use EventValues to get them
+// with their concrete type.
+type Event struct {
+	Type     EventType
+	Resource ResourceType
+	ID       string
+	Old      any
+	New      any
+	Changes  []FieldChange // Only set for updates
+}
+
+// Changed returns the change made to the given JSON field, if any.
+//
+// Example:
+//
+//	if change, ok := event.Changed("checked"); ok && change.New == true {
+//	 // the task was completed
+//	}
+func (e Event) Changed(field string) (FieldChange, bool) {
+	for _, change := range e.Changes {
+		if change.Field == field {
+			return change, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+// EventValues returns the Old and New values of an event as T. A value is nil
+// if it is not set or not a T.
+//
+// Example:
+//
+//	oldTask, newTask := todoist.EventValues[todoist.Task](event)
+func EventValues[T any](e Event) (oldValue *T, newValue *T) {
+	if v, ok := e.Old.(T); ok {
+		oldValue = &v
+	}
+	if v, ok := e.New.(T); ok {
+		newValue = &v
+	}
+	return oldValue, newValue
+}
+
+// appendEvent appends an event to events if it is not nil. Updates that do
+// not change any field are skipped.
+func appendEvent[T any](
+	events *[]Event,
+	kind resourceKind[T],
+	eventType EventType,
+	id string,
+	oldValue *T,
+	newValue *T,
+) {
+	if events == nil {
+		return
+	}
+
+	event := Event{Type: eventType, Resource: kind.resource, ID: id}
+	if oldValue != nil {
+		event.Old = *oldValue
+	}
+	if newValue != nil {
+		event.New = *newValue
+	}
+	if eventType == EventUpdated {
+		event.Changes = diffFields(*oldValue, *newValue)
+		if len(event.Changes) == 0 {
+			return
+		}
+	}
+	*events = append(*events, event)
+}
+
+// diffFields compares the JSON representations of two values and returns the
+// fields that differ, sorted by name.
+func diffFields(oldValue any, newValue any) []FieldChange {
+	oldFields := jsonFields(oldValue)
+	newFields := jsonFields(newValue)
+
+	keys := map[string]bool{}
+	for key := range oldFields {
+		keys[key] = true
+	}
+	for key := range newFields {
+		keys[key] = true
+	}
+
+	var changes []FieldChange
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		if !reflect.DeepEqual(oldFields[key], newFields[key]) {
+			changes = append(changes, FieldChange{
+				Field: key,
+				Old:   oldFields[key],
+				New:   newFields[key],
+			})
+		}
+	}
+	return changes
+}
+
+// jsonFields returns the fields of v as decoded from its JSON representation.
+func jsonFields(v any) map[string]any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// EventHandler is called by a Watcher for every matching event.
+type EventHandler func(Event)
+
+// subscription is a handler registered with Watcher.On.
+type subscription struct {
+	id        int
+	resource  ResourceType
+	eventType EventType
+	handler   EventHandler
+}
+
+// Default polling settings used by a Watcher when none are set.
+const (
+	DefaultWatchInterval   = 30 * time.Second
+	DefaultWatchMaxBackoff = 10 * time.Minute
+)
+
+// Watcher polls the Sync API for incremental changes, applies them to a Store
+// and dispatches the resulting events to the registered handlers.
+//
+// Example:
+//
+//	watcher := todoist.NewWatcher(client.Sync, todoist.NewStore())
+//	watcher.On(todoist.ResourceItem, todoist.EventUpdated, func(e todoist.Event) {
+//	 if change, ok := e.Changed("checked"); ok && change.New == true {
+//	  fmt.Println("completed task", e.ID)
+//	 }
+//	})
+//	err := watcher.Run(ctx)
+type Watcher struct {
+	Sync          *Sync
+	Store         *Store
+	ResourceTypes []string      // Defaults to all resource types
+	Interval      time.Duration // Defaults to DefaultWatchInterval
+
+	// EmitInitialSync controls whether the events of the first full sync,
+	// which reports every resource as created, are dispatched.
+	EmitInitialSync bool
+
+	// MaxBackoff bounds the delay between polls after transient failures.
+	// Defaults to DefaultWatchMaxBackoff.
+	MaxBackoff time.Duration
+
+	// ErrorHandler, if set, is called by Run with every transient error
+	// before it waits to poll again.
+	ErrorHandler func(error)
+
+	mu            sync.Mutex
+	subscriptions []subscription
+	nextID        int
+}
+
+// NewWatcher returns a Watcher that keeps st up to date using s.
+func NewWatcher(s *Sync, st *Store) *Watcher {
+	return &Watcher{
+		Sync:          s,
+		Store:         st,
+		ResourceTypes: []string{"all"},
+		Interval:      DefaultWatchInterval,
+	}
+}
+
+// On registers a handler for the events matching the given resource and event
+// types. An empty resource or event type matches any value. The returned
+// function removes the handler. A dispatch that is already in progress may
+// still call it once.
+func (w *Watcher) On(
+	resource ResourceType,
+	eventType EventType,
+	handler EventHandler,
+) (remove func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextID++
+	id := w.nextID
+	w.subscriptions = append(w.subscriptions, subscription{
+		id:        id,
+		resource:  resource,
+		eventType: eventType,
+		handler:   handler,
+	})
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.subscriptions = slices.DeleteFunc(
+			w.subscriptions,
+			func(sub subscription) bool { return sub.id == id },
+		)
+	}
+}
+
+// Poll performs a single sync, applies it to the store and dispatches the
+// resulting events, which are also returned.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	initial := w.Store.SyncToken() == "*"
+
+	resourceTypes := w.ResourceTypes
+	if len(resourceTypes) == 0 {
+		resourceTypes = []string{"all"}
+	}
+
+	w.Sync.SyncToken = w.Store.SyncToken()
+	resp, err := w.Sync.ReadResources(ctx, resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+	events := w.Store.ApplyWithEvents(resp)
+	if err := w.Store.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	if initial && !w.EmitInitialSync {
+		return nil, nil
+	}
+	w.dispatch(events)
+	return events, nil
+}
+
+// dispatch calls the matching handlers for every event.
+func (w *Watcher) dispatch(events []Event) {
+	w.mu.Lock()
+	subscriptions := slices.Clone(w.subscriptions)
+	w.mu.Unlock()
+
+	for _, event := range events {
+		for _, sub := range subscriptions {
+			if sub.resource != "" && sub.resource != event.Resource {
+				continue
+			}
+			if sub.eventType != "" && sub.eventType != event.Type {
+				continue
+			}
+			sub.handler(event)
+		}
+	}
+}
+
+// Run polls until the context is done or a sync fails with a permanent error,
+// and returns that error or the context error.
+//
+// Transient errors, such as network failures, rate limiting and server
+// errors, do not stop Run. They are passed to ErrorHandler and the next poll
+// is delayed, starting at Interval and doubling after every consecutive
+// failure up to MaxBackoff.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	maxBackoff := w.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultWatchMaxBackoff
+	}
+	backoff := &RetryPolicy{
+		InitialBackoff: interval,
+		MaxBackoff:     max(maxBackoff, interval),
+		Multiplier:     2,
+	}
+
+	var failures int
+	for {
+		delay := interval
+		if _, err := w.Poll(ctx); err != nil {
+			if ctx.Err() != nil || !isTransient(err) {
+				return err
+			}
+			if w.ErrorHandler != nil {
+				w.ErrorHandler(err)
+			}
+			failures++
+			delay = backoff.backoff(failures)
+		} else {
+			failures = 0
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// isTransient reports whether a failed sync may succeed if it is tried again
+// later.
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError ||
+			apiErr.StatusCode == http.StatusRequestTimeout ||
+			apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Events runs the watcher in a new goroutine and delivers every event on the
+// returned channel. Both channels are closed when the watcher stops, and the
+// error channel receives the error returned by Run. The handler used to
+// deliver the events is removed when the watcher stops, and events dispatched
+// afterwards by other calls to Poll are dropped.
+func (w *Watcher) Events(ctx context.Context) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	// mu guards closed, so that no event is sent once the channel is closed.
+	// Senders hold it while they wait, and give up as soon as ctx is done.
+	var mu sync.Mutex
+	var closed bool
+
+	ctx, cancel := context.WithCancel(ctx)
+	remove := w.On("", "", func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+
+	go func() {
+		defer close(errs)
+		err := w.Run(ctx)
+
+		remove()
+		cancel()
+		mu.Lock()
+		closed = true
+		close(events)
+		mu.Unlock()
+
+		errs <- err
+	}()
+	return events, errs
+}
@@ -0,0 +1,132 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newWatcherTestClient returns a client whose requests are not retried, so
+// that every failure reaches the watcher.
+func newWatcherTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	return newTestClient(
+		t,
+		handler,
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 1}),
+	)
+}
+
+func TestWatcherOnRemove(t *testing.T) {
+	w := NewWatcher(nil, NewStore())
+	var calls int
+	remove := w.On(ResourceItem, "", func(Event) { calls++ })
+	w.On(ResourceProject, "", func(Event) { t.Error("unexpected call") })
+
+	w.dispatch([]Event{{Type: EventCreated, Resource: ResourceItem}})
+	remove()
+	w.dispatch([]Event{{Type: EventCreated, Resource: ResourceItem}})
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if len(w.subscriptions) != 1 {
+		t.Errorf("%d subscriptions left, want 1", len(w.subscriptions))
+	}
+}
+
+func TestWatcherRunKeepsPollingAfterTransientErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests atomic.Int32
+	c := newWatcherTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch n := requests.Add(1); {
+		case n <= 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case n == 3:
+			fmt.Fprint(w, `{"sync_token":"token","full_sync":true}`)
+		default:
+			cancel()
+			fmt.Fprint(w, `{"sync_token":"token","full_sync":false}`)
+		}
+	})
+
+	w := NewWatcher(c.Sync, NewStore())
+	w.Interval = time.Millisecond
+	var failures []error
+	w.ErrorHandler = func(err error) { failures = append(failures, err) }
+
+	err := w.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+	if len(failures) != 2 {
+		t.Errorf("ErrorHandler called %d times, want 2", len(failures))
+	}
+	if w.Store.SyncToken() != "token" {
+		t.Errorf("SyncToken = %q, want %q", w.Store.SyncToken(), "token")
+	}
+}
+
+func TestWatcherRunStopsOnPermanentError(t *testing.T) {
+	var requests atomic.Int32
+	c := newWatcherTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	w := NewWatcher(c.Sync, NewStore())
+	w.Interval = time.Millisecond
+	w.ErrorHandler = func(err error) { t.Errorf("unexpected error %v", err) }
+
+	err := w.Run(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Run error = %v, want ErrUnauthorized", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
+
+func TestWatcherEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newWatcherTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"sync_token": "token",
+			"full_sync": true,
+			"items": [{"id": "1", "content": "Task"}]
+		}`)
+	})
+	w := NewWatcher(c.Sync, NewStore())
+	w.Interval = time.Hour
+	w.EmitInitialSync = true
+
+	events, errs := w.Events(ctx)
+	event := <-events
+	if event.Type != EventCreated || event.ID != "1" {
+		t.Errorf("event = %s %s, want created 1", event.Type, event.ID)
+	}
+
+	cancel()
+	for range events {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	if len(w.subscriptions) != 0 {
+		t.Errorf("%d subscriptions left, want 0", len(w.subscriptions))
+	}
+
+	// Polling after the watcher stopped must not send on the closed channel.
+	w.Store = NewStore()
+	w.EmitInitialSync = true
+	if _, err := w.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+}
@@ -0,0 +1,368 @@
+package todoist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FilterContext holds the data needed to evaluate filter queries against
+// tasks locally: the projects, sections and collaborators that names in the
+// query refer to, the current user for "me", and the current time and
+// timezone for date terms.
+type FilterContext struct {
+	Projects      []Project
+	Sections      []Section
+	Collaborators []Collaborator
+	User          *User          // Resolves "me" and "others"
+	Now           time.Time      // Defaults to time.Now()
+	Location      *time.Location // Defaults to the user's timezone, or time.Local
+}
+
+// FilterContext returns a FilterContext using the resources of the store
+// and the timezone of the synced user.
+func (st *Store) FilterContext() *FilterContext {
+	return &FilterContext{
+		Projects:      st.Projects(),
+		Sections:      st.Sections(),
+		Collaborators: st.Collaborators(),
+		User:          st.User(),
+	}
+}
+
+// FilterTasks returns the active tasks of the store matching a query in the
+// Todoist filter syntax, ordered as Tasks orders them.
+func (st *Store) FilterTasks(query string) ([]Task, error) {
+	return st.FilterContext().FilterTasks(query, st.Tasks())
+}
+
+// FilterTasks returns the tasks matching a query in the Todoist filter
+// syntax, keeping their order. Like the filter endpoint, it skips completed
+// and deleted tasks and returns the tasks matching any of the queries
+// separated by commas.
+//
+// Example:
+//
+//	tasks, err := store.FilterContext().FilterTasks("(today | overdue) & #Work", tasks)
+func (fc *FilterContext) FilterTasks(
+	query string,
+	tasks []Task,
+) ([]Task, error) {
+	nodes, err := ParseFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	e := fc.evaluator()
+	var matches []Task
+	for _, task := range tasks {
+		if task.Checked || task.IsDeleted {
+			continue
+		}
+		for _, node := range nodes {
+			ok, err := e.match(node, task)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, task)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// Match reports whether task matches a parsed filter node.
+func (fc *FilterContext) Match(node FilterNode, task Task) (bool, error) {
+	return fc.evaluator().match(node, task)
+}
+
+// filterEvaluator holds the lookups derived from a FilterContext.
+type filterEvaluator struct {
+	now      time.Time
+	today    time.Time
+	loc      *time.Location
+	userID   string
+	projects map[string]Project
+	sections map[string]Section
+	people   []Collaborator
+}
+
+func (fc *FilterContext) evaluator() *filterEvaluator {
+	loc := fc.Location
+	if loc == nil {
+		loc = userLocation(fc.User)
+	}
+	now := fc.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.In(loc)
+
+	e := &filterEvaluator{
+		now:      now,
+		today:    startOfDay(now),
+		loc:      loc,
+		projects: make(map[string]Project, len(fc.Projects)),
+		sections: make(map[string]Section, len(fc.Sections)),
+		people:   slices.Clone(fc.Collaborators),
+	}
+	if fc.User != nil {
+		e.userID = fc.User.ID
+		e.people = append(e.people, Collaborator{
+			ID:       fc.User.ID,
+			FullName: fc.User.FullName,
+			Email:    fc.User.Email,
+		})
+	}
+	for _, project := range fc.Projects {
+		e.projects[project.ID] = project
+	}
+	for _, section := range fc.Sections {
+		e.sections[section.ID] = section
+	}
+	return e
+}
+
+// userLocation returns the timezone of the user, or time.Local if it is not
+// known.
+func userLocation(user *User) *time.Location {
+	if user == nil {
+		return time.Local
+	}
+	name, _ := user.TZInfo["timezone"].(string)
+	if name == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func (e *filterEvaluator) match(node FilterNode, task Task) (bool, error) {
+	switch n := node.(type) {
+	case FilterAnd:
+		for _, operand := range n.Operands {
+			ok, err := e.match(operand, task)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case FilterOr:
+		for _, operand := range n.Operands {
+			ok, err := e.match(operand, task)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case FilterNot:
+		ok, err := e.match(n.Operand, task)
+		return !ok, err
+	case FilterTerm:
+		return e.matchTerm(n, task)
+	}
+	return false, fmt.Errorf("%w: node %T", ErrUnsupportedFilter, node)
+}
+
+func (e *filterEvaluator) matchTerm(term FilterTerm, task Task) (bool, error) {
+	var date time.Time
+	if term.isDate() {
+		var err error
+		date, err = parseFilterDate(term.Value, e.today)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	switch term.Kind {
+	case FilterTermAll:
+		return true, nil
+	case FilterTermToday:
+		return e.dueOn(task, e.today), nil
+	case FilterTermTomorrow:
+		return e.dueOn(task, e.today.AddDate(0, 0, 1)), nil
+	case FilterTermOverdue:
+		return e.overdue(task), nil
+	case FilterTermNoDate:
+		return task.Due == nil, nil
+	case FilterTermNoTime:
+		return task.Due != nil && !task.Due.HasTime(), nil
+	case FilterTermRecurring:
+		return task.Due != nil && task.Due.IsRecurring, nil
+	case FilterTermNextDays:
+		day, ok := e.dueDay(task)
+		end := e.today.AddDate(0, 0, term.Number)
+		return ok && !day.Before(e.today) && day.Before(end), nil
+	case FilterTermDate:
+		return e.dueOn(task, date), nil
+	case FilterTermDueBefore:
+		t, ok := dueTime(task.Due, e.loc)
+		return ok && t.Before(date), nil
+	case FilterTermDueAfter:
+		t, ok := dueTime(task.Due, e.loc)
+		return ok && !t.Before(date.AddDate(0, 0, 1)), nil
+	case FilterTermDeadlineBefore:
+		t, ok := e.deadline(task)
+		return ok && t.Before(date), nil
+	case FilterTermDeadlineAfter:
+		t, ok := e.deadline(task)
+		return ok && t.After(date), nil
+	case FilterTermNoDeadline:
+		return task.Deadline == nil, nil
+	case FilterTermCreatedBefore:
+		return task.AddedAt != nil && task.AddedAt.Before(date), nil
+	case FilterTermCreatedAfter:
+		return task.AddedAt != nil &&
+			!task.AddedAt.Before(date.AddDate(0, 0, 1)), nil
+	case FilterTermPriority:
+		// Filters use the priorities shown in the apps, where p1 is the
+		// highest, while the API uses 4 for the highest priority.
+		return task.Priority == 5-term.Number, nil
+	case FilterTermLabel:
+		for _, label := range task.Labels {
+			if matchFilterName(term.Value, label) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case FilterTermNoLabels:
+		return len(task.Labels) == 0, nil
+	case FilterTermProject:
+		project, ok := e.projects[task.ProjectID]
+		return ok && matchFilterName(term.Value, project.Name), nil
+	case FilterTermProjectTree:
+		return e.inProjectTree(term.Value, task.ProjectID), nil
+	case FilterTermSection:
+		if task.SectionID == nil {
+			return false, nil
+		}
+		section, ok := e.sections[*task.SectionID]
+		return ok && matchFilterName(term.Value, section.Name), nil
+	case FilterTermSubtask:
+		return task.ParentID != nil, nil
+	case FilterTermAssigned:
+		return task.ResponsibleUID != nil, nil
+	case FilterTermNoAssignee:
+		return task.ResponsibleUID == nil, nil
+	case FilterTermAssignedTo:
+		return e.matchPerson(term.Value, task.ResponsibleUID), nil
+	case FilterTermAssignedBy:
+		return e.matchPerson(term.Value, task.AssignedByUID), nil
+	case FilterTermSearch:
+		return matchFilterName("*"+term.Value+"*", task.Content), nil
+	}
+	return false, fmt.Errorf("%w: %q", ErrUnsupportedFilter, term.String())
+}
+
+// dueDay returns the day the task is due on in the evaluator's timezone.
+func (e *filterEvaluator) dueDay(task Task) (time.Time, bool) {
+	t, ok := dueTime(task.Due, e.loc)
+	if !ok {
+		return time.Time{}, false
+	}
+	return startOfDay(t), true
+}
+
+func (e *filterEvaluator) dueOn(task Task, day time.Time) bool {
+	due, ok := e.dueDay(task)
+	return ok && due.Equal(day)
+}
+
+// overdue reports whether the task is due before now, or before today for
+// full-day tasks.
+func (e *filterEvaluator) overdue(task Task) bool {
+	t, ok := dueTime(task.Due, e.loc)
+	if !ok {
+		return false
+	}
+	if task.Due.HasTime() {
+		return t.Before(e.now)
+	}
+	return t.Before(e.today)
+}
+
+func (e *filterEvaluator) deadline(task Task) (time.Time, bool) {
+	if task.Deadline == nil {
+		return time.Time{}, false
+	}
+	t, err := task.Deadline.Time(e.loc)
+	return t, err == nil
+}
+
+// inProjectTree reports whether projectID is a project matching name or one
+// of its subprojects.
+func (e *filterEvaluator) inProjectTree(name string, projectID string) bool {
+	for id := projectID; id != ""; {
+		project, ok := e.projects[id]
+		if !ok {
+			return false
+		}
+		if matchFilterName(name, project.Name) {
+			return true
+		}
+		id = stringValue(project.ParentID)
+	}
+	return false
+}
+
+// matchPerson reports whether uid is the person named in a filter: "me",
+// "others", or a collaborator matching by name or email.
+func (e *filterEvaluator) matchPerson(person string, uid *string) bool {
+	if uid == nil {
+		return false
+	}
+	switch strings.ToLower(person) {
+	case "me":
+		return e.userID != "" && *uid == e.userID
+	case "others":
+		return e.userID != "" && *uid != e.userID
+	}
+	for _, c := range e.people {
+		if c.ID != *uid {
+			continue
+		}
+		if matchFilterName(person, c.FullName) ||
+			matchFilterName(person, c.Name) ||
+			matchFilterName(person, c.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFilterName reports whether name matches pattern, ignoring case. The
+// pattern can contain * to match any sequence of characters.
+func matchFilterName(pattern string, name string) bool {
+	if name == "" {
+		return false
+	}
+	pattern = strings.ToLower(pattern)
+	name = strings.ToLower(name)
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
@@ -0,0 +1,157 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMatchFilterName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"work", "Work", true},
+		{"work", "Workout", false},
+		{"wo*", "Work", true},
+		{"*rk", "Work", true},
+		{"w*k", "Work", true},
+		{"*or*", "Work", true},
+		{"*", "anything", true},
+		{"w*x", "Work", false},
+		{"a*a", "a", false},
+		{"*", "", false},
+	}
+	for _, tt := range tests {
+		got := matchFilterName(tt.pattern, tt.name)
+		if got != tt.want {
+			t.Errorf(
+				"matchFilterName(%q, %q) = %v, want %v",
+				tt.pattern,
+				tt.name,
+				got,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestFilterTasksDatesAroundMidnight(t *testing.T) {
+	// 23:30 on March 10 in a timezone five hours behind UTC, which is
+	// already March 11 in UTC.
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	fc := &FilterContext{
+		Now:      time.Date(2024, 3, 10, 23, 30, 0, 0, loc),
+		Location: loc,
+	}
+
+	due := func(date string) *Due { return &Due{Date: date} }
+	tasks := []Task{
+		{ID: "yesterday", Due: due("2024-03-09")},
+		{ID: "today", Due: due("2024-03-10")},
+		{ID: "tomorrow", Due: due("2024-03-11")},
+		{ID: "floating-earlier", Due: due("2024-03-10T22:00:00")},
+		{ID: "floating-later", Due: due("2024-03-10T23:45:00")},
+		{ID: "fixed-today", Due: due("2024-03-11T03:00:00Z")},    // 22:00 local
+		{ID: "fixed-tomorrow", Due: due("2024-03-11T06:00:00Z")}, // 01:00 local
+		{ID: "no-date"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"today", []string{
+			"today",
+			"floating-earlier",
+			"floating-later",
+			"fixed-today",
+		}},
+		{"tomorrow", []string{"tomorrow", "fixed-tomorrow"}},
+		{"overdue", []string{"yesterday", "floating-earlier", "fixed-today"}},
+		{"no date", []string{"no-date"}},
+		{"no time", []string{"yesterday", "today", "tomorrow"}},
+		{"due before: tomorrow", []string{
+			"yesterday",
+			"today",
+			"floating-earlier",
+			"floating-later",
+			"fixed-today",
+		}},
+		{"due after: today", []string{"tomorrow", "fixed-tomorrow"}},
+		{"2 days", []string{
+			"today",
+			"tomorrow",
+			"floating-earlier",
+			"floating-later",
+			"fixed-today",
+			"fixed-tomorrow",
+		}},
+	}
+	for _, tt := range tests {
+		matches, err := fc.FilterTasks(tt.query, tasks)
+		if err != nil {
+			t.Errorf("FilterTasks(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if got := taskIDs(matches); !slices.Equal(got, tt.want) {
+			t.Errorf("FilterTasks(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFilterTasksOperatorsAndNames(t *testing.T) {
+	fc := &FilterContext{
+		Projects: []Project{
+			{ID: "w", Name: "Work"},
+			{ID: "m", Name: "Meetings", ParentID: strPtr("w")},
+			{ID: "h", Name: "Work & Home"},
+		},
+		Now:      time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+	}
+	tasks := []Task{
+		{ID: "1", ProjectID: "w", Priority: 4, Labels: []string{"waiting"}},
+		{ID: "2", ProjectID: "m", Priority: 1},
+		{ID: "3", ProjectID: "h", Priority: 4, Content: "Buy milk"},
+		{ID: "4", ProjectID: "w", Checked: true},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"#Work", []string{"1"}},
+		{"##Work", []string{"1", "2"}},
+		{`#Work\ \& Home`, []string{"3"}},
+		{`#"work & home"`, []string{"3"}},
+		{"#Wor*", []string{"1", "3"}},
+		{"p1 & !@waiting", []string{"3"}},
+		{"p1 | #Meetings & p4", []string{"1", "2", "3"}},
+		{"(p1 | #Meetings) & p4", []string{"2"}},
+		{"@waiting, search: milk", []string{"1", "3"}},
+	}
+	for _, tt := range tests {
+		matches, err := fc.FilterTasks(tt.query, tasks)
+		if err != nil {
+			t.Errorf("FilterTasks(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if got := taskIDs(matches); !slices.Equal(got, tt.want) {
+			t.Errorf("FilterTasks(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := []string{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func strPtr(s string) *string {
+	return &s
+}
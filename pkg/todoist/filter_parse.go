@@ -0,0 +1,567 @@
+package todoist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrFilterSyntax is returned by ParseFilter when a query is malformed,
+	// e.g. because of unbalanced parentheses.
+	ErrFilterSyntax = errors.New("invalid filter syntax")
+
+	// ErrUnsupportedFilter is returned by ParseFilter for terms of the filter
+	// language that are valid in Todoist but cannot be evaluated locally.
+	ErrUnsupportedFilter = errors.New("unsupported filter")
+)
+
+// FilterNode is a node of a parsed filter query: FilterAnd, FilterOr,
+// FilterNot or FilterTerm. String returns the node in the filter syntax.
+type FilterNode interface {
+	String() string
+	query() FilterQuery
+}
+
+// FilterAnd matches the tasks matching all of its operands.
+type FilterAnd struct {
+	Operands []FilterNode
+}
+
+func (n FilterAnd) String() string {
+	return n.query().String()
+}
+
+func (n FilterAnd) query() FilterQuery {
+	return And(filterNodeQueries(n.Operands)...)
+}
+
+// FilterOr matches the tasks matching any of its operands.
+type FilterOr struct {
+	Operands []FilterNode
+}
+
+func (n FilterOr) String() string {
+	return n.query().String()
+}
+
+func (n FilterOr) query() FilterQuery {
+	return Or(filterNodeQueries(n.Operands)...)
+}
+
+// FilterNot matches the tasks not matching its operand.
+type FilterNot struct {
+	Operand FilterNode
+}
+
+func (n FilterNot) String() string {
+	return n.query().String()
+}
+
+func (n FilterNot) query() FilterQuery {
+	return Not(n.Operand.query())
+}
+
+func filterNodeQueries(nodes []FilterNode) []FilterQuery {
+	queries := make([]FilterQuery, len(nodes))
+	for i, node := range nodes {
+		queries[i] = node.query()
+	}
+	return queries
+}
+
+// FilterTermKind is the kind of a FilterTerm.
+type FilterTermKind string
+
+const (
+	FilterTermAll            FilterTermKind = "all"
+	FilterTermToday          FilterTermKind = "today"
+	FilterTermTomorrow       FilterTermKind = "tomorrow"
+	FilterTermOverdue        FilterTermKind = "overdue"
+	FilterTermNoDate         FilterTermKind = "no_date"
+	FilterTermNoTime         FilterTermKind = "no_time"
+	FilterTermRecurring      FilterTermKind = "recurring"
+	FilterTermNextDays       FilterTermKind = "next_days"       // Number is the number of days
+	FilterTermDate           FilterTermKind = "date"            // Value is the date
+	FilterTermDueBefore      FilterTermKind = "due_before"      // Value is the date
+	FilterTermDueAfter       FilterTermKind = "due_after"       // Value is the date
+	FilterTermDeadlineBefore FilterTermKind = "deadline_before" // Value is the date
+	FilterTermDeadlineAfter  FilterTermKind = "deadline_after"  // Value is the date
+	FilterTermNoDeadline     FilterTermKind = "no_deadline"
+	FilterTermCreatedBefore  FilterTermKind = "created_before" // Value is the date
+	FilterTermCreatedAfter   FilterTermKind = "created_after"  // Value is the date
+	FilterTermPriority       FilterTermKind = "priority"       // Number is 1 to 4, 1 being the highest
+	FilterTermLabel          FilterTermKind = "label"          // Value is the name
+	FilterTermNoLabels       FilterTermKind = "no_labels"
+	FilterTermProject        FilterTermKind = "project"      // Value is the name
+	FilterTermProjectTree    FilterTermKind = "project_tree" // Value is the name
+	FilterTermSection        FilterTermKind = "section"      // Value is the name
+	FilterTermSubtask        FilterTermKind = "subtask"
+	FilterTermAssigned       FilterTermKind = "assigned"
+	FilterTermNoAssignee     FilterTermKind = "no_assignee"
+	FilterTermAssignedTo     FilterTermKind = "assigned_to" // Value is the person
+	FilterTermAssignedBy     FilterTermKind = "assigned_by" // Value is the person
+	FilterTermSearch         FilterTermKind = "search"      // Value is the text
+)
+
+// FilterTerm is a single condition of a filter query, such as "today",
+// "#Work" or "p1". Names can contain * as a wildcard.
+type FilterTerm struct {
+	Kind   FilterTermKind
+	Value  string
+	Number int
+}
+
+func (n FilterTerm) String() string {
+	return n.query().String()
+}
+
+func (n FilterTerm) query() FilterQuery {
+	switch n.Kind {
+	case FilterTermAll:
+		return filterAtom("view all")
+	case FilterTermToday:
+		return FilterToday()
+	case FilterTermTomorrow:
+		return FilterTomorrow()
+	case FilterTermOverdue:
+		return FilterOverdue()
+	case FilterTermNoDate:
+		return FilterNoDate()
+	case FilterTermNoTime:
+		return FilterNoTime()
+	case FilterTermRecurring:
+		return FilterRecurring()
+	case FilterTermNextDays:
+		return FilterNextDays(n.Number)
+	case FilterTermDate:
+		return FilterDate(n.Value)
+	case FilterTermDueBefore:
+		return FilterDueBefore(n.Value)
+	case FilterTermDueAfter:
+		return FilterDueAfter(n.Value)
+	case FilterTermDeadlineBefore:
+		return FilterDeadlineBefore(n.Value)
+	case FilterTermDeadlineAfter:
+		return FilterDeadlineAfter(n.Value)
+	case FilterTermNoDeadline:
+		return FilterNoDeadline()
+	case FilterTermCreatedBefore:
+		return FilterCreatedBefore(n.Value)
+	case FilterTermCreatedAfter:
+		return FilterCreatedAfter(n.Value)
+	case FilterTermPriority:
+		return FilterPriority(n.Number)
+	case FilterTermLabel:
+		return FilterLabel(n.Value)
+	case FilterTermNoLabels:
+		return FilterNoLabels()
+	case FilterTermProject:
+		return FilterProject(n.Value)
+	case FilterTermProjectTree:
+		return FilterProjectWithSubprojects(n.Value)
+	case FilterTermSection:
+		return FilterSection(n.Value)
+	case FilterTermSubtask:
+		return FilterSubtask()
+	case FilterTermAssigned:
+		return FilterAssigned()
+	case FilterTermNoAssignee:
+		return FilterNoAssignee()
+	case FilterTermAssignedTo:
+		return FilterAssignedTo(n.Value)
+	case FilterTermAssignedBy:
+		return FilterAssignedBy(n.Value)
+	case FilterTermSearch:
+		return FilterSearch(n.Value)
+	}
+	return FilterRaw(n.Value)
+}
+
+// ParseFilter parses a query in the Todoist filter syntax. Queries separated
+// by commas are returned as separate nodes.
+//
+// Errors wrap ErrFilterSyntax for malformed queries and ErrUnsupportedFilter
+// for terms that cannot be evaluated locally, such as "shared" or
+// "due before: next monday".
+func ParseFilter(query string) ([]FilterNode, error) {
+	tokens, err := lexFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{query: query, tokens: tokens}
+	var nodes []FilterNode
+	for {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		tok, ok := p.next()
+		if !ok {
+			return nodes, nil
+		}
+		if tok.op != ',' {
+			return nil, p.errorf(tok, "unexpected %q", tok.text())
+		}
+	}
+}
+
+// filterToken is an operator or a term of a filter query. Terms are unescaped
+// and trimmed.
+type filterToken struct {
+	op     rune // 0 for terms
+	term   string
+	offset int
+}
+
+// text returns the operator or term of the token.
+func (t filterToken) text() string {
+	if t.op != 0 {
+		return string(t.op)
+	}
+	return t.term
+}
+
+// lexFilter splits a filter query into tokens. Operators lose their meaning
+// when escaped with a backslash or inside double quotes, e.g. in
+// #"Work & Home".
+func lexFilter(query string) ([]filterToken, error) {
+	var tokens []filterToken
+	var term strings.Builder
+	termOffset := -1
+
+	flush := func() {
+		if text := strings.TrimSpace(term.String()); text != "" {
+			tokens = append(tokens, filterToken{term: text, offset: termOffset})
+		}
+		term.Reset()
+		termOffset = -1
+	}
+
+	escaped, quoted := false, false
+	for i, r := range query {
+		switch {
+		case escaped:
+			term.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+			term.WriteRune(r)
+		case strings.ContainsRune("&|!(),", r):
+			flush()
+			tokens = append(tokens, filterToken{op: r, offset: i})
+			continue
+		default:
+			term.WriteRune(r)
+		}
+		if termOffset < 0 && r != ' ' {
+			termOffset = i
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("%w: trailing backslash", ErrFilterSyntax)
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unclosed quote", ErrFilterSyntax)
+	}
+	flush()
+	return tokens, nil
+}
+
+// filterParser is a recursive descent parser over filter tokens. Not binds
+// tighter than and, which binds tighter than or.
+type filterParser struct {
+	query  string
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return tok, ok
+}
+
+func (p *filterParser) errorf(
+	tok filterToken,
+	format string,
+	args ...any,
+) error {
+	return fmt.Errorf(
+		"%w: %s at offset %d in %q",
+		ErrFilterSyntax,
+		fmt.Sprintf(format, args...),
+		tok.offset,
+		p.query,
+	)
+}
+
+func (p *filterParser) parseOr() (FilterNode, error) {
+	return p.parseBinary('|', p.parseAnd, func(nodes []FilterNode) FilterNode {
+		return FilterOr{Operands: nodes}
+	})
+}
+
+func (p *filterParser) parseAnd() (FilterNode, error) {
+	return p.parseBinary('&', p.parseUnary, func(nodes []FilterNode) FilterNode {
+		return FilterAnd{Operands: nodes}
+	})
+}
+
+// parseBinary parses operands separated by op.
+func (p *filterParser) parseBinary(
+	op rune,
+	parseOperand func() (FilterNode, error),
+	combine func([]FilterNode) FilterNode,
+) (FilterNode, error) {
+	node, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []FilterNode{node}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.op != op {
+			break
+		}
+		p.pos++
+
+		node, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return combine(nodes), nil
+}
+
+func (p *filterParser) parseUnary() (FilterNode, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: unexpected end of %q",
+			ErrFilterSyntax,
+			p.query,
+		)
+	}
+
+	switch tok.op {
+	case 0:
+		term, err := parseFilterTerm(tok.term)
+		if err != nil {
+			return nil, fmt.Errorf("%w at offset %d", err, tok.offset)
+		}
+		return term, nil
+	case '!':
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return FilterNot{Operand: node}, nil
+	case '(':
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.next()
+		if !ok || closing.op != ')' {
+			return nil, p.errorf(tok, "unclosed parenthesis")
+		}
+		return node, nil
+	}
+	return nil, p.errorf(tok, "unexpected %q", tok.text())
+}
+
+var (
+	filterPriorityPattern = regexp.MustCompile(`^p([1-4])$`)
+	filterDaysPattern     = regexp.MustCompile(`^(?:next )?(\d+) days?$`)
+)
+
+// filterKeywords maps the keywords of the filter language to term kinds.
+var filterKeywords = map[string]FilterTermKind{
+	"all":         FilterTermAll,
+	"view all":    FilterTermAll,
+	"today":       FilterTermToday,
+	"tod":         FilterTermToday,
+	"tomorrow":    FilterTermTomorrow,
+	"tom":         FilterTermTomorrow,
+	"overdue":     FilterTermOverdue,
+	"od":          FilterTermOverdue,
+	"no date":     FilterTermNoDate,
+	"no due date": FilterTermNoDate,
+	"no time":     FilterTermNoTime,
+	"recurring":   FilterTermRecurring,
+	"no deadline": FilterTermNoDeadline,
+	"no labels":   FilterTermNoLabels,
+	"subtask":     FilterTermSubtask,
+	"subtasks":    FilterTermSubtask,
+	"assigned":    FilterTermAssigned,
+	"no assignee": FilterTermNoAssignee,
+}
+
+// filterPrefixes maps the "name: value" terms of the filter language to term
+// kinds.
+var filterPrefixes = []struct {
+	prefix string
+	kind   FilterTermKind
+}{
+	{"due before:", FilterTermDueBefore},
+	{"date before:", FilterTermDueBefore},
+	{"due after:", FilterTermDueAfter},
+	{"date after:", FilterTermDueAfter},
+	{"due:", FilterTermDate},
+	{"date:", FilterTermDate},
+	{"deadline before:", FilterTermDeadlineBefore},
+	{"deadline after:", FilterTermDeadlineAfter},
+	{"created before:", FilterTermCreatedBefore},
+	{"created after:", FilterTermCreatedAfter},
+	{"assigned to:", FilterTermAssignedTo},
+	{"assigned by:", FilterTermAssignedBy},
+	{"search:", FilterTermSearch},
+}
+
+// parseFilterTerm classifies a single term of a filter query.
+func parseFilterTerm(text string) (FilterTerm, error) {
+	lower := strings.ToLower(text)
+
+	switch {
+	case strings.HasPrefix(text, "##"):
+		return FilterTerm{Kind: FilterTermProjectTree, Value: text[2:]}, nil
+	case strings.HasPrefix(text, "#"):
+		return FilterTerm{Kind: FilterTermProject, Value: text[1:]}, nil
+	case strings.HasPrefix(text, "@"):
+		return FilterTerm{Kind: FilterTermLabel, Value: text[1:]}, nil
+	case strings.HasPrefix(text, "/"):
+		return FilterTerm{Kind: FilterTermSection, Value: text[1:]}, nil
+	case lower == "no priority":
+		return FilterTerm{Kind: FilterTermPriority, Number: 4}, nil
+	}
+
+	if kind, ok := filterKeywords[lower]; ok {
+		return FilterTerm{Kind: kind}, nil
+	}
+	if m := filterPriorityPattern.FindStringSubmatch(lower); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return FilterTerm{Kind: FilterTermPriority, Number: n}, nil
+	}
+	if m := filterDaysPattern.FindStringSubmatch(lower); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return FilterTerm{}, fmt.Errorf("%w: %q", ErrFilterSyntax, text)
+		}
+		return FilterTerm{Kind: FilterTermNextDays, Number: n}, nil
+	}
+
+	for _, p := range filterPrefixes {
+		if !strings.HasPrefix(lower, p.prefix) {
+			continue
+		}
+		term := FilterTerm{
+			Kind:  p.kind,
+			Value: strings.TrimSpace(text[len(p.prefix):]),
+		}
+		if term.Value == "" {
+			return FilterTerm{}, fmt.Errorf(
+				"%w: missing value in %q",
+				ErrFilterSyntax,
+				text,
+			)
+		}
+		if term.isDate() {
+			if _, err := parseFilterDate(term.Value, time.Now()); err != nil {
+				return FilterTerm{}, err
+			}
+		}
+		return term, nil
+	}
+
+	// Anything else is only supported as a date.
+	if _, err := parseFilterDate(text, time.Now()); err != nil {
+		return FilterTerm{}, fmt.Errorf("%w: %q", ErrUnsupportedFilter, text)
+	}
+	return FilterTerm{Kind: FilterTermDate, Value: text}, nil
+}
+
+// isDate reports whether the value of the term is a date.
+func (n FilterTerm) isDate() bool {
+	switch n.Kind {
+	case FilterTermDate,
+		FilterTermDueBefore,
+		FilterTermDueAfter,
+		FilterTermDeadlineBefore,
+		FilterTermDeadlineAfter,
+		FilterTermCreatedBefore,
+		FilterTermCreatedAfter:
+		return true
+	}
+	return false
+}
+
+// filterDateLayouts are the absolute date formats supported in filters.
+// Layouts without a year refer to the current year.
+var filterDateLayouts = []struct {
+	layout  string
+	hasYear bool
+}{
+	{"2006-01-02", true},
+	{"2006/01/02", true},
+	{"Jan 2 2006", true},
+	{"January 2 2006", true},
+	{"2 Jan 2006", true},
+	{"2 January 2006", true},
+	{"Jan 2", false},
+	{"January 2", false},
+	{"2 Jan", false},
+	{"2 January", false},
+}
+
+// parseFilterDate parses a date of a filter query, returning midnight of
+// that day in the location of today. Relative dates are relative to today.
+func parseFilterDate(value string, today time.Time) (time.Time, error) {
+	loc := today.Location()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today", "tod":
+		return today, nil
+	case "tomorrow", "tom":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for _, l := range filterDateLayouts {
+		t, err := time.ParseInLocation(l.layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if !l.hasYear {
+			t = t.AddDate(today.Year()-t.Year(), 0, 0)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%w: date %q", ErrUnsupportedFilter, value)
+}
@@ -0,0 +1,146 @@
+package todoist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	today := FilterTerm{Kind: FilterTermToday}
+	overdue := FilterTerm{Kind: FilterTermOverdue}
+	p1 := FilterTerm{Kind: FilterTermPriority, Number: 1}
+	work := FilterTerm{Kind: FilterTermProject, Value: "Work"}
+
+	tests := []struct {
+		query string
+		want  []FilterNode
+	}{
+		{"today", []FilterNode{today}},
+		{
+			"today | overdue & p1",
+			[]FilterNode{FilterOr{Operands: []FilterNode{
+				today,
+				FilterAnd{Operands: []FilterNode{overdue, p1}},
+			}}},
+		},
+		{
+			"today & overdue | p1",
+			[]FilterNode{FilterOr{Operands: []FilterNode{
+				FilterAnd{Operands: []FilterNode{today, overdue}},
+				p1,
+			}}},
+		},
+		{
+			"!today & p1",
+			[]FilterNode{FilterAnd{Operands: []FilterNode{
+				FilterNot{Operand: today},
+				p1,
+			}}},
+		},
+		{
+			"!(today | p1)",
+			[]FilterNode{FilterNot{Operand: FilterOr{Operands: []FilterNode{
+				today,
+				p1,
+			}}}},
+		},
+		{
+			"(today | overdue) & #Work",
+			[]FilterNode{FilterAnd{Operands: []FilterNode{
+				FilterOr{Operands: []FilterNode{today, overdue}},
+				work,
+			}}},
+		},
+		{"today, overdue & p1", []FilterNode{
+			today,
+			FilterAnd{Operands: []FilterNode{overdue, p1}},
+		}},
+		{"TOD,od", []FilterNode{today, overdue}},
+		{`#Work\ \& Home`, []FilterNode{
+			FilterTerm{Kind: FilterTermProject, Value: "Work & Home"},
+		}},
+		{`#"Work & Home" | @"a, b"`, []FilterNode{FilterOr{Operands: []FilterNode{
+			FilterTerm{Kind: FilterTermProject, Value: "Work & Home"},
+			FilterTerm{Kind: FilterTermLabel, Value: "a, b"},
+		}}}},
+		{`@say\"hi\"`, []FilterNode{
+			FilterTerm{Kind: FilterTermLabel, Value: `say"hi"`},
+		}},
+		{"##Work", []FilterNode{
+			FilterTerm{Kind: FilterTermProjectTree, Value: "Work"},
+		}},
+		{"/Next", []FilterNode{FilterTerm{Kind: FilterTermSection, Value: "Next"}}},
+		{"7 days", []FilterNode{FilterTerm{Kind: FilterTermNextDays, Number: 7}}},
+		{"no priority", []FilterNode{
+			FilterTerm{Kind: FilterTermPriority, Number: 4},
+		}},
+		{"due before: 2024-01-05", []FilterNode{
+			FilterTerm{Kind: FilterTermDueBefore, Value: "2024-01-05"},
+		}},
+		{"assigned to: me", []FilterNode{
+			FilterTerm{Kind: FilterTermAssignedTo, Value: "me"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseFilter(tt.query)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFilter(%q) = %#v, want %#v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{"", ErrFilterSyntax},
+		{"(today", ErrFilterSyntax},
+		{"today)", ErrFilterSyntax},
+		{"today &", ErrFilterSyntax},
+		{"& today", ErrFilterSyntax},
+		{"today,", ErrFilterSyntax},
+		{"today | | p1", ErrFilterSyntax},
+		{`#"Work`, ErrFilterSyntax},
+		{`today \`, ErrFilterSyntax},
+		{"due before:", ErrFilterSyntax},
+		{"shared", ErrUnsupportedFilter},
+		{"due before: next monday", ErrUnsupportedFilter},
+	}
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.query)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ParseFilter(%q) error = %v, want %v", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestFilterNodeStringRoundTrip(t *testing.T) {
+	queries := []string{
+		"(today | overdue) & #Work",
+		`#Work\ \& Home`,
+		"!(p1 | p2) & @waiting",
+	}
+	for _, query := range queries {
+		nodes, err := ParseFilter(query)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) failed: %v", query, err)
+		}
+		again, err := ParseFilter(nodes[0].String())
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) failed: %v", nodes[0].String(), err)
+		}
+		if !reflect.DeepEqual(nodes, again) {
+			t.Errorf(
+				"%q renders as %q, which parses differently",
+				query,
+				nodes[0].String(),
+			)
+		}
+	}
+}
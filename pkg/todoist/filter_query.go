@@ -0,0 +1,303 @@
+package todoist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// filterPrecedence is how tightly a FilterQuery binds, used to decide where
+// parentheses are needed when queries are combined.
+type filterPrecedence int
+
+const (
+	filterPrecedenceNone filterPrecedence = iota
+	filterPrecedenceOr
+	filterPrecedenceAnd
+	filterPrecedenceAtom
+)
+
+// FilterQuery is a query in the Todoist filter syntax, built with the Filter*
+// functions and combined with And, Or and Not. Names given to the builders
+// are escaped, so they can contain characters such as & or parentheses.
+//
+// Example:
+//
+//	query := todoist.And(
+//	 todoist.Or(todoist.FilterToday(), todoist.FilterOverdue()),
+//	 todoist.FilterProjectWithSubprojects("Work"),
+//	 todoist.Not(todoist.FilterLabel("waiting")),
+//	)
+//	tasks, err := client.GetTasksByFilter(ctx, query.String(), "en")
+type FilterQuery struct {
+	expr string
+	op   filterPrecedence
+}
+
+// String returns the query in the Todoist filter syntax.
+func (q FilterQuery) String() string {
+	return q.expr
+}
+
+// IsZero reports whether the query is empty.
+func (q FilterQuery) IsZero() bool {
+	return q.expr == ""
+}
+
+// FilterQueries joins several queries with commas. The filter endpoint
+// returns the tasks matching any of them, while saved filters show each
+// query as a separate list.
+func FilterQueries(queries ...FilterQuery) string {
+	parts := make([]string, 0, len(queries))
+	for _, q := range queries {
+		if !q.IsZero() {
+			parts = append(parts, q.expr)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EscapeFilterName escapes the characters that have a meaning in the filter
+// syntax, so that name can be used as a project, section, label or person
+// name.
+func EscapeFilterName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch r {
+		case '\\', '"', '&', '|', '!', '(', ')', ',':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FilterRaw returns a query using expr as is. expr is treated as a single
+// term, so it is wrapped in parentheses when combined with other queries.
+func FilterRaw(expr string) FilterQuery {
+	return FilterQuery{expr: expr, op: filterPrecedenceNone}
+}
+
+func filterAtom(expr string) FilterQuery {
+	return FilterQuery{expr: expr, op: filterPrecedenceAtom}
+}
+
+// And returns a query matching the tasks that match every query. Empty
+// queries are ignored.
+func And(queries ...FilterQuery) FilterQuery {
+	return joinFilters(" & ", filterPrecedenceAnd, queries)
+}
+
+// Or returns a query matching the tasks that match any of the queries. Empty
+// queries are ignored.
+func Or(queries ...FilterQuery) FilterQuery {
+	return joinFilters(" | ", filterPrecedenceOr, queries)
+}
+
+// Not returns a query matching the tasks that do not match q.
+func Not(q FilterQuery) FilterQuery {
+	if q.IsZero() {
+		return q
+	}
+	if q.op == filterPrecedenceAtom {
+		return filterAtom("!" + q.expr)
+	}
+	return filterAtom("!(" + q.expr + ")")
+}
+
+// joinFilters joins queries with op. Operands joined with the same operator
+// are flattened, and any other compound operand is wrapped in parentheses so
+// the result does not depend on operator precedence.
+func joinFilters(
+	sep string,
+	op filterPrecedence,
+	queries []FilterQuery,
+) FilterQuery {
+	var operands []FilterQuery
+	for _, q := range queries {
+		if !q.IsZero() {
+			operands = append(operands, q)
+		}
+	}
+	if len(operands) < 2 {
+		if len(operands) == 1 {
+			return operands[0]
+		}
+		return FilterQuery{}
+	}
+
+	parts := make([]string, len(operands))
+	for i, q := range operands {
+		if q.op == filterPrecedenceAtom || q.op == op {
+			parts[i] = q.expr
+		} else {
+			parts[i] = "(" + q.expr + ")"
+		}
+	}
+	return FilterQuery{expr: strings.Join(parts, sep), op: op}
+}
+
+// FilterToday matches tasks due today.
+func FilterToday() FilterQuery {
+	return filterAtom("today")
+}
+
+// FilterTomorrow matches tasks due tomorrow.
+func FilterTomorrow() FilterQuery {
+	return filterAtom("tomorrow")
+}
+
+// FilterOverdue matches overdue tasks.
+func FilterOverdue() FilterQuery {
+	return filterAtom("overdue")
+}
+
+// FilterNoDate matches tasks without a due date.
+func FilterNoDate() FilterQuery {
+	return filterAtom("no date")
+}
+
+// FilterNoTime matches tasks with a due date but no time.
+func FilterNoTime() FilterQuery {
+	return filterAtom("no time")
+}
+
+// FilterRecurring matches recurring tasks.
+func FilterRecurring() FilterQuery {
+	return filterAtom("recurring")
+}
+
+// FilterNextDays matches tasks due in the next n days, including today.
+func FilterNextDays(n int) FilterQuery {
+	return filterAtom(fmt.Sprintf("next %d days", n))
+}
+
+// FilterDate matches tasks due on date, which can be any date understood by
+// Todoist, such as "2025-01-31", "Jan 31" or "next monday".
+func FilterDate(date string) FilterQuery {
+	return filterAtom(date)
+}
+
+// FilterDueBefore matches tasks due before date.
+func FilterDueBefore(date string) FilterQuery {
+	return filterAtom("due before: " + date)
+}
+
+// FilterDueAfter matches tasks due after date.
+func FilterDueAfter(date string) FilterQuery {
+	return filterAtom("due after: " + date)
+}
+
+// FilterDueBetween matches tasks due after from and before to, excluding
+// both dates.
+func FilterDueBetween(from string, to string) FilterQuery {
+	return And(FilterDueAfter(from), FilterDueBefore(to))
+}
+
+// FilterDeadlineBefore matches tasks with a deadline before date.
+func FilterDeadlineBefore(date string) FilterQuery {
+	return filterAtom("deadline before: " + date)
+}
+
+// FilterDeadlineAfter matches tasks with a deadline after date.
+func FilterDeadlineAfter(date string) FilterQuery {
+	return filterAtom("deadline after: " + date)
+}
+
+// FilterNoDeadline matches tasks without a deadline.
+func FilterNoDeadline() FilterQuery {
+	return filterAtom("no deadline")
+}
+
+// FilterCreatedBefore matches tasks created before date.
+func FilterCreatedBefore(date string) FilterQuery {
+	return filterAtom("created before: " + date)
+}
+
+// FilterCreatedAfter matches tasks created after date.
+func FilterCreatedAfter(date string) FilterQuery {
+	return filterAtom("created after: " + date)
+}
+
+// FilterPriority matches tasks with the given priority as shown in the
+// Todoist apps, where 1 is the highest priority. Note that the API uses the
+// opposite order in Task.Priority, where 4 is the highest.
+func FilterPriority(priority int) FilterQuery {
+	return filterAtom(fmt.Sprintf("p%d", priority))
+}
+
+// FilterLabel matches tasks with the given label. The name can contain * as
+// a wildcard.
+func FilterLabel(name string) FilterQuery {
+	return filterAtom("@" + EscapeFilterName(name))
+}
+
+// FilterNoLabels matches tasks without labels.
+func FilterNoLabels() FilterQuery {
+	return filterAtom("no labels")
+}
+
+// FilterProject matches tasks in the given project, excluding its
+// subprojects.
+func FilterProject(name string) FilterQuery {
+	return filterAtom("#" + EscapeFilterName(name))
+}
+
+// FilterProjectWithSubprojects matches tasks in the given project and all of
+// its subprojects.
+func FilterProjectWithSubprojects(name string) FilterQuery {
+	return filterAtom("##" + EscapeFilterName(name))
+}
+
+// FilterSection matches tasks in sections with the given name, in any
+// project. Combine it with FilterProject to match a single section.
+func FilterSection(name string) FilterQuery {
+	return filterAtom("/" + EscapeFilterName(name))
+}
+
+// FilterNoSection matches tasks that are not in a section.
+func FilterNoSection() FilterQuery {
+	return filterAtom("!/*")
+}
+
+// FilterSubtask matches tasks that are subtasks of another task.
+func FilterSubtask() FilterQuery {
+	return filterAtom("subtask")
+}
+
+// FilterAssignedTo matches tasks assigned to the given person, which can be
+// a name, an email or "me".
+func FilterAssignedTo(person string) FilterQuery {
+	return filterAtom("assigned to: " + EscapeFilterName(person))
+}
+
+// FilterAssignedToMe matches tasks assigned to the current user.
+func FilterAssignedToMe() FilterQuery {
+	return filterAtom("assigned to: me")
+}
+
+// FilterAssignedToOthers matches tasks assigned to someone other than the
+// current user.
+func FilterAssignedToOthers() FilterQuery {
+	return filterAtom("assigned to: others")
+}
+
+// FilterAssignedBy matches tasks assigned by the given person, which can be
+// a name, an email or "me".
+func FilterAssignedBy(person string) FilterQuery {
+	return filterAtom("assigned by: " + EscapeFilterName(person))
+}
+
+// FilterAssigned matches tasks assigned to anyone.
+func FilterAssigned() FilterQuery {
+	return filterAtom("assigned")
+}
+
+// FilterNoAssignee matches tasks that are not assigned to anyone.
+func FilterNoAssignee() FilterQuery {
+	return filterAtom("no assignee")
+}
+
+// FilterSearch matches tasks whose content contains text.
+func FilterSearch(text string) FilterQuery {
+	return filterAtom("search: " + EscapeFilterName(text))
+}
@@ -0,0 +1,47 @@
+package todoist
+
+import "testing"
+
+func TestFilterQueryEscapesNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		query FilterQuery
+		want  string
+	}{
+		{
+			"quotes",
+			FilterProject(`Say "hi"`),
+			`#Say \"hi\"`,
+		},
+		{
+			"operators",
+			FilterLabel("R&D | ops"),
+			`@R\&D \| ops`,
+		},
+		{
+			"parentheses and commas",
+			FilterSection("Later (maybe), not now!"),
+			`/Later \(maybe\)\, not now\!`,
+		},
+		{
+			"backslash",
+			FilterProjectWithSubprojects(`a\b`),
+			`##a\\b`,
+		},
+		{
+			"combined",
+			And(
+				Or(FilterProject(`"Home"`), FilterProject("Work & Play")),
+				Not(FilterLabel("(waiting)")),
+			),
+			`(#\"Home\" | #Work \& Play) & !@\(waiting\)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.want {
+				t.Errorf("query = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package todoist
 
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
 type Filter struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
@@ -10,3 +16,363 @@ type Filter struct {
 	IsFavorite bool   `json:"is_favorite"`
 	IsFrozen   bool   `json:"is_frozen"`
 }
+
+// CheckFilterLimits checks that one more filter can be created under the
+// limits of a plan. existing are the current filters of the user. Errors wrap
+// ErrPlanLimitExceeded.
+func CheckFilterLimits(plan UserPlanInfo, existing []Filter) error {
+	return checkFilterCount(plan, countFilters(existing)+1)
+}
+
+// CheckFilterLimits checks that one more filter can be created under the
+// synced plan limits of the user, as CheckFilterLimits does. It returns an
+// error wrapping ErrInvalidArgument if the plan limits have not been synced.
+func (st *Store) CheckFilterLimits() error {
+	limits := st.UserPlanLimits()
+	if limits == nil {
+		return fmt.Errorf(
+			"%w: plan limits are unknown, sync user_plan_limits first",
+			ErrInvalidArgument,
+		)
+	}
+	return CheckFilterLimits(limits.Current, st.Filters())
+}
+
+// countFilters returns the number of filters that are not deleted.
+func countFilters(filters []Filter) int {
+	var n int
+	for _, filter := range filters {
+		if !filter.IsDeleted {
+			n++
+		}
+	}
+	return n
+}
+
+// checkFilterCount checks that the user can have total filters on the plan.
+func checkFilterCount(plan UserPlanInfo, total int) error {
+	if !plan.Filters {
+		return fmt.Errorf(
+			"%w: filters are not available on the %s plan",
+			ErrPlanLimitExceeded,
+			plan.PlanName,
+		)
+	}
+	if total > plan.MaxFilters {
+		return fmt.Errorf(
+			"%w: at most %d filters, %d requested",
+			ErrPlanLimitExceeded,
+			plan.MaxFilters,
+			total,
+		)
+	}
+	return nil
+}
+
+// GetFilters returns all filters ordered by item_order. Filters are only
+// available through the Sync API, so this performs a full sync of the filters
+// without changing the SyncToken of client.Sync.
+func (c *Client) GetFilters(ctx context.Context) ([]Filter, error) {
+	resp, err := c.Sync.readAll(ctx, "filters")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filters: %w", err)
+	}
+	return activeFilters(resp.Filters), nil
+}
+
+// activeFilters returns the filters that are not deleted, ordered by
+// item_order.
+func activeFilters(filters []Filter) []Filter {
+	active := slices.DeleteFunc(slices.Clone(filters), func(f Filter) bool {
+		return f.IsDeleted
+	})
+	slices.SortFunc(active, compareFilters)
+	return active
+}
+
+// CreateFilter creates a filter with a filter_add command and returns its ID.
+// The limits of the user's plan are checked against st as
+// Store.CheckFilterLimits does, or, when st is nil, against the filters and
+// plan limits fetched from the API.
+func (c *Client) CreateFilter(
+	ctx context.Context,
+	args FilterAddArgs,
+	st *Store,
+) (string, error) {
+	if args.Name == "" || args.Query == "" {
+		return "", fmt.Errorf(
+			"%w: filter name and query are required",
+			ErrInvalidArgument,
+		)
+	}
+	if st != nil {
+		if err := st.CheckFilterLimits(); err != nil {
+			return "", err
+		}
+	} else if err := c.checkFilterLimits(ctx); err != nil {
+		return "", err
+	}
+
+	command := NewFilterAddCommand(args)
+	result, err := c.Sync.execute(ctx, command)
+	if err != nil {
+		return "", fmt.Errorf("failed to create filter: %w", err)
+	}
+	return result.TempIDMapping[command.TempID], nil
+}
+
+// checkFilterLimits fetches the filters and plan limits of the user and
+// checks that one more filter can be created.
+func (c *Client) checkFilterLimits(ctx context.Context) error {
+	resp, err := c.Sync.readAll(ctx, "filters", "user_plan_limits")
+	if err != nil {
+		return fmt.Errorf("failed to get filters: %w", err)
+	}
+	return CheckFilterLimits(resp.UserPlanLimits.Current, resp.Filters)
+}
+
+// UpdateFilter updates the fields of a filter that are not nil in args.
+func (c *Client) UpdateFilter(ctx context.Context, args FilterUpdateArgs) error {
+	if args.ID == "" {
+		return fmt.Errorf("%w: filter ID is required", ErrInvalidArgument)
+	}
+	if (args.Name != nil && *args.Name == "") ||
+		(args.Query != nil && *args.Query == "") {
+		return fmt.Errorf(
+			"%w: filter name and query cannot be empty",
+			ErrInvalidArgument,
+		)
+	}
+
+	_, err := c.Sync.execute(ctx, NewFilterUpdateCommand(args))
+	if err != nil {
+		return fmt.Errorf("failed to update filter: %w", err)
+	}
+	return nil
+}
+
+// DeleteFilter deletes the filter with the given ID.
+func (c *Client) DeleteFilter(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: filter ID is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewFilterDeleteCommand(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete filter: %w", err)
+	}
+	return nil
+}
+
+// ReorderFilters sets the order of filters to the order of ids. Filters that
+// are not in ids keep their item_order.
+func (c *Client) ReorderFilters(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mapping := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, ok := mapping[id]; ok {
+			return fmt.Errorf("%w: duplicate filter ID %q", ErrInvalidArgument, id)
+		}
+		mapping[id] = i + 1
+	}
+
+	_, err := c.Sync.execute(ctx, NewFilterUpdateOrdersCommand(mapping))
+	if err != nil {
+		return fmt.Errorf("failed to reorder filters: %w", err)
+	}
+	return nil
+}
+
+// FilterSpec declares a filter that should exist. Filters are matched to
+// specs by name.
+type FilterSpec struct {
+	Name       string
+	Query      string
+	Color      string // The current color is kept when empty
+	IsFavorite bool
+}
+
+// FilterChanges are the changes needed to turn the existing filters into the
+// desired ones.
+type FilterChanges struct {
+	Create []FilterAddArgs
+	Update []FilterUpdateArgs
+	Delete []Filter
+}
+
+// IsEmpty reports whether there is nothing to change.
+func (c *FilterChanges) IsEmpty() bool {
+	return len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0
+}
+
+// Commands returns the commands that apply the changes, deletions first so
+// that the filters they free count against the plan limits before new ones
+// are created.
+func (c *FilterChanges) Commands() []Command {
+	var commands []Command
+	for _, filter := range c.Delete {
+		commands = append(commands, NewFilterDeleteCommand(filter.ID))
+	}
+	for _, args := range c.Update {
+		commands = append(commands, NewFilterUpdateCommand(args))
+	}
+	for _, args := range c.Create {
+		commands = append(commands, NewFilterAddCommand(args))
+	}
+	return commands
+}
+
+// PlanFilters compares existing filters to the desired specs and returns the
+// changes to apply. The filters are ordered as the specs, followed by the
+// existing filters that are not in specs. These are deleted when
+// deleteUnlisted is set, and otherwise kept in their current order. When
+// several existing filters have the name of a spec, the first one in order is
+// updated and the others are treated as unlisted.
+func PlanFilters(
+	existing []Filter,
+	specs []FilterSpec,
+	deleteUnlisted bool,
+) (*FilterChanges, error) {
+	byName := make(map[string]Filter, len(existing))
+	for _, filter := range activeFilters(existing) {
+		if _, ok := byName[filter.Name]; !ok {
+			byName[filter.Name] = filter
+		}
+	}
+
+	changes := &FilterChanges{}
+	matched := make(map[string]bool, len(specs))
+	names := make(map[string]bool, len(specs))
+	for i, spec := range specs {
+		if spec.Name == "" || spec.Query == "" {
+			return nil, fmt.Errorf(
+				"%w: filter spec %d needs a name and a query",
+				ErrInvalidArgument,
+				i,
+			)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf(
+				"%w: duplicate filter spec %q",
+				ErrInvalidArgument,
+				spec.Name,
+			)
+		}
+		names[spec.Name] = true
+		order := i + 1
+
+		filter, ok := byName[spec.Name]
+		if !ok {
+			changes.Create = append(changes.Create, FilterAddArgs{
+				Name:       spec.Name,
+				Query:      spec.Query,
+				Color:      spec.Color,
+				ItemOrder:  order,
+				IsFavorite: spec.IsFavorite,
+			})
+			continue
+		}
+		matched[filter.ID] = true
+
+		update := FilterUpdateArgs{ID: filter.ID}
+		if filter.Query != spec.Query {
+			update.Query = &spec.Query
+		}
+		if spec.Color != "" && filter.Color != spec.Color {
+			update.Color = &spec.Color
+		}
+		if filter.IsFavorite != spec.IsFavorite {
+			update.IsFavorite = &spec.IsFavorite
+		}
+		if filter.ItemOrder != order {
+			update.ItemOrder = &order
+		}
+		if update != (FilterUpdateArgs{ID: filter.ID}) {
+			changes.Update = append(changes.Update, update)
+		}
+	}
+
+	next := len(specs)
+	for _, filter := range activeFilters(existing) {
+		if matched[filter.ID] {
+			continue
+		}
+		if deleteUnlisted {
+			changes.Delete = append(changes.Delete, filter)
+			continue
+		}
+		next++
+		if order := next; filter.ItemOrder != order {
+			changes.Update = append(changes.Update, FilterUpdateArgs{
+				ID:        filter.ID,
+				ItemOrder: &order,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// ReconcileFiltersOptions holds the options of ReconcileFilters.
+type ReconcileFiltersOptions struct {
+	// DeleteUnlisted deletes the filters that are not in the specs.
+	DeleteUnlisted bool
+
+	// DryRun only computes the changes, without applying them.
+	DryRun bool
+}
+
+// ReconcileFilters makes the filters of the account match specs, as
+// PlanFilters describes, and returns the changes it applied. Before anything
+// is changed, the resulting number of filters is checked against the plan
+// limits of the user, and an error wrapping ErrPlanLimitExceeded is returned
+// if they would be exceeded.
+//
+// The changes are sent on their own, without the commands queued in
+// client.Sync. Changes that fail are reported by the returned error and are
+// not queued.
+//
+// Example:
+//
+//	changes, err := client.ReconcileFilters(ctx, []todoist.FilterSpec{
+//	 {Name: "Urgent", Query: "p1 & (today | overdue)", Color: "red"},
+//	 {Name: "Waiting", Query: "@waiting"},
+//	}, &todoist.ReconcileFiltersOptions{DeleteUnlisted: true})
+func (c *Client) ReconcileFilters(
+	ctx context.Context,
+	specs []FilterSpec,
+	opts *ReconcileFiltersOptions,
+) (*FilterChanges, error) {
+	if opts == nil {
+		opts = &ReconcileFiltersOptions{}
+	}
+
+	resp, err := c.Sync.readAll(ctx, "filters", "user_plan_limits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filters: %w", err)
+	}
+
+	changes, err := PlanFilters(resp.Filters, specs, opts.DeleteUnlisted)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes.Create) > 0 {
+		total := countFilters(resp.Filters) - len(changes.Delete) +
+			len(changes.Create)
+		err := checkFilterCount(resp.UserPlanLimits.Current, total)
+		if err != nil {
+			return changes, err
+		}
+	}
+	if opts.DryRun || changes.IsEmpty() {
+		return changes, nil
+	}
+
+	_, err = c.Sync.execute(ctx, changes.Commands()...)
+	if err != nil {
+		return changes, fmt.Errorf("failed to reconcile filters: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,148 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestReconcileFiltersLeavesQueueAlone(t *testing.T) {
+	server := &syncServer{fail: []string{CommandFilterAdd}}
+	server.t = t
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("commands") != "" {
+			server.ServeHTTP(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(SyncReadResponse{
+			SyncToken: "token",
+			FullSync:  true,
+			Filters: []Filter{
+				{ID: "1", Name: "Work", Query: "#Work", ItemOrder: 1},
+			},
+			UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+				Filters:    true,
+				MaxFilters: 10,
+			}},
+		})
+	})
+	queued := NewItemCloseCommand("queued")
+	c.Sync.AddCommand(queued)
+
+	changes, err := c.ReconcileFilters(context.Background(), []FilterSpec{
+		{Name: "Work", Query: "#Work & today"},
+		{Name: "Home", Query: "#Home"},
+	}, nil)
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("err = %v, want the error of the failed filter_add", err)
+	}
+	if len(changes.Create) != 1 || len(changes.Update) != 1 {
+		t.Errorf(
+			"planned %d creations and %d updates, want 1 and 1",
+			len(changes.Create),
+			len(changes.Update),
+		)
+	}
+	for _, command := range server.requests[0] {
+		if command.UUID == queued.UUID {
+			t.Error("the queued command was sent with the filter changes")
+		}
+	}
+	if len(c.Sync.Commands) != 1 || c.Sync.Commands[0].UUID != queued.UUID {
+		t.Errorf("queue = %v, want only the command queued before", c.Sync.Commands)
+	}
+}
+
+func TestReconcileFiltersChecksPlanLimits(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(SyncReadResponse{
+			Filters: []Filter{{ID: "1", Name: "Work", Query: "#Work"}},
+			UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+				Filters:    true,
+				MaxFilters: 1,
+			}},
+		})
+	})
+
+	_, err := c.ReconcileFilters(context.Background(), []FilterSpec{
+		{Name: "Home", Query: "#Home"},
+	}, nil)
+	if !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("err = %v, want ErrPlanLimitExceeded", err)
+	}
+}
+
+func TestStoreCheckFilterLimits(t *testing.T) {
+	st := NewStore()
+	if err := st.CheckFilterLimits(); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("err = %v, want ErrInvalidArgument for unknown limits", err)
+	}
+
+	st.Apply(&SyncReadResponse{
+		SyncToken: "token",
+		FullSync:  true,
+		Filters:   []Filter{{ID: "1", Name: "Work", Query: "#Work"}},
+		UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+			PlanName:   "free",
+			Filters:    true,
+			MaxFilters: 1,
+		}},
+	})
+	if err := st.CheckFilterLimits(); !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("err = %v, want ErrPlanLimitExceeded", err)
+	}
+}
+
+func TestCreateFilterChecksPlanLimits(t *testing.T) {
+	server := &syncServer{}
+	server.t = t
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("commands") != "" {
+			server.ServeHTTP(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(SyncReadResponse{
+			SyncToken: "token",
+			FullSync:  true,
+			Filters:   []Filter{{ID: "1", Name: "Work", Query: "#Work"}},
+			UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+				PlanName:   "free",
+				Filters:    true,
+				MaxFilters: 1,
+			}},
+		})
+	})
+	args := FilterAddArgs{Name: "Home", Query: "#Home"}
+
+	_, err := c.CreateFilter(context.Background(), args, nil)
+	if !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("err = %v, want ErrPlanLimitExceeded", err)
+	}
+	_, err = c.CreateFilter(context.Background(), args, NewStore())
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("err = %v, want ErrInvalidArgument for unknown limits", err)
+	}
+
+	st := NewStore()
+	st.Apply(&SyncReadResponse{
+		SyncToken: "token",
+		FullSync:  true,
+		UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+			PlanName:   "pro",
+			Filters:    true,
+			MaxFilters: 150,
+		}},
+	})
+	id, err := c.CreateFilter(context.Background(), args, st)
+	if err != nil {
+		t.Fatalf("CreateFilter failed: %v", err)
+	}
+	if len(server.requests) != 1 || id != "real-"+server.requests[0][0].TempID {
+		t.Errorf("id = %q, want the real ID of the created filter", id)
+	}
+}
@@ -0,0 +1,31 @@
+package todoist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client sending its requests to a test server
+// serving handler. Retries use short delays so that tests run quickly.
+func newTestClient(
+	t *testing.T,
+	handler http.HandlerFunc,
+	options ...Option,
+) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+	policy.Jitter = 0
+
+	options = append(
+		[]Option{WithBaseURL(server.URL), WithRetryPolicy(policy)},
+		options...,
+	)
+	return NewClient("test-token", options...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // Label represents a Todoist label. We do not need to have a struct for
@@ -14,6 +15,7 @@ type Label struct {
 	Color      string `json:"color"`
 	Order      *int   `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted,omitempty"` // only set in sync responses
 }
 
 // SharedLabelFilters holds the required filter parameters for retrieving shared
@@ -29,7 +31,7 @@ type SharedLabelOptions struct {
 	NewName string `json:"new_name,omitempty"`
 }
 
-// LabelOptions holds the parameters for creating or updating a label.
+// LabelOptions holds the parameters for creating a label.
 type LabelOptions struct {
 	Name       string `json:"name,omitempty"`
 	Order      int    `json:"order,omitempty"`
@@ -37,6 +39,20 @@ type LabelOptions struct {
 	IsFavorite bool   `json:"is_favorite,omitempty"`
 }
 
+// LabelUpdate holds the parameters for updating a label. Only the fields that
+// are set are updated, so fields can be cleared by setting them to their zero
+// value, e.g. IsFavorite to Some(false).
+type LabelUpdate struct {
+	Name       Optional[string] `json:"name"`
+	Order      Optional[int]    `json:"order"`
+	Color      Optional[string] `json:"color"`
+	IsFavorite Optional[bool]   `json:"is_favorite"`
+}
+
+func (u LabelUpdate) MarshalJSON() ([]byte, error) {
+	return marshalUpdate(u)
+}
+
 // SharedLabels returns a set of unique strings containing labels from active
 // tasks.
 // By default, the names of a user's personal labels will also be included.
@@ -46,7 +62,7 @@ func (c *Client) SharedLabels(
 	ctx context.Context,
 	filters *SharedLabelFilters,
 ) ([]string, *string, error) {
-	res, err := c.request(ctx, "GET", "/labels/shared", filters, nil)
+	res, err := c.request(ctx, "GET", "/labels/shared", nil, filters)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get shared labels: %w", err)
 	}
@@ -64,6 +80,26 @@ func (c *Client) SharedLabels(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllSharedLabels returns an iterator over all shared labels, requesting
+// further pages as needed.
+func (c *Client) AllSharedLabels(
+	ctx context.Context,
+	filters *SharedLabelFilters,
+) iter.Seq2[string, error] {
+	var f SharedLabelFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]string, *string, error) {
+			f.Cursor = cursor
+			return c.SharedLabels(ctx, &f)
+		},
+	)
+}
+
 // GetLabels returns a list of all user labels.
 func (c *Client) GetLabels(
 	ctx context.Context,
@@ -84,6 +120,26 @@ func (c *Client) GetLabels(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllLabels returns an iterator over all user labels, requesting further pages
+// as needed.
+func (c *Client) AllLabels(
+	ctx context.Context,
+	filters *PaginationFilters,
+) iter.Seq2[Label, error] {
+	var f PaginationFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]Label, *string, error) {
+			f.Cursor = cursor
+			return c.GetLabels(ctx, &f)
+		},
+	)
+}
+
 // CreateLabel creates a new personal label with the given name.
 // The name is required and will override the value in the LabelOptions.
 func (c *Client) CreateLabel(
@@ -92,7 +148,7 @@ func (c *Client) CreateLabel(
 	options *LabelOptions,
 ) (*Label, error) {
 	if name == "" {
-		return nil, fmt.Errorf("label name is required")
+		return nil, fmt.Errorf("%w: label name is required", ErrInvalidArgument)
 	}
 
 	if options == nil {
@@ -121,7 +177,7 @@ func (c *Client) CreateLabel(
 // considered successful.
 func (c *Client) SharedLabelsRemove(ctx context.Context, name string) error {
 	if name == "" {
-		return fmt.Errorf("label name is required")
+		return fmt.Errorf("%w: label name is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
@@ -146,10 +202,10 @@ func (c *Client) SharedLabelsRename(
 	newName string,
 ) error {
 	if name == "" {
-		return fmt.Errorf("label name is required")
+		return fmt.Errorf("%w: label name is required", ErrInvalidArgument)
 	}
 	if newName == "" {
-		return fmt.Errorf("new label name is required")
+		return fmt.Errorf("%w: new label name is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
@@ -171,7 +227,7 @@ func (c *Client) SharedLabelsRename(
 // will be removed from tasks.
 func (c *Client) DeleteLabel(ctx context.Context, id string) error {
 	if id == "" {
-		return fmt.Errorf("label ID is required")
+		return fmt.Errorf("%w: label ID is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
@@ -192,7 +248,7 @@ func (c *Client) DeleteLabel(ctx context.Context, id string) error {
 // GetLabel returns a label by its ID.
 func (c *Client) GetLabel(ctx context.Context, id string) (*Label, error) {
 	if id == "" {
-		return nil, fmt.Errorf("label ID is required")
+		return nil, fmt.Errorf("%w: label ID is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(ctx, "GET", fmt.Sprintf("/labels/%s", id), nil, nil)
@@ -210,25 +266,25 @@ func (c *Client) GetLabel(ctx context.Context, id string) (*Label, error) {
 	return &label, nil
 }
 
-// UpdateLabel updates a label by its ID. The ID is required, and the
-// LabelOptions are required to specify the fields to update.
+// UpdateLabel updates a label by its ID. The ID is required, and only the
+// fields that are set in update are changed.
 func (c *Client) UpdateLabel(
 	ctx context.Context,
 	id string,
-	options *LabelOptions,
+	update *LabelUpdate,
 ) (*Label, error) {
 	if id == "" {
-		return nil, fmt.Errorf("label ID is required")
+		return nil, fmt.Errorf("%w: label ID is required", ErrInvalidArgument)
 	}
-	if options == nil {
-		return nil, fmt.Errorf("options are required")
+	if update == nil {
+		return nil, fmt.Errorf("%w: update is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
 		ctx,
 		"POST",
 		fmt.Sprintf("/labels/%s", id),
-		options,
+		update,
 		nil,
 	)
 	if err != nil {
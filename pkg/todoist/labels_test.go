@@ -0,0 +1,46 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestAllSharedLabelsSendsFiltersInQuery(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if body, _ := io.ReadAll(r.Body); len(body) != 0 {
+			t.Errorf("request body = %s, want none", body)
+		}
+		query := r.URL.Query()
+		if query.Get("omit_personal") != "true" {
+			t.Errorf("omit_personal = %q, want true", query.Get("omit_personal"))
+		}
+		if requests == 1 {
+			fmt.Fprint(w, `{"results":["a","b"],"next_cursor":"next"}`)
+			return
+		}
+		if query.Get("cursor") != "next" {
+			t.Errorf("cursor = %q, want %q", query.Get("cursor"), "next")
+		}
+		fmt.Fprint(w, `{"results":["c"],"next_cursor":null}`)
+	})
+
+	var labels []string
+	for label, err := range c.AllSharedLabels(
+		context.Background(),
+		&SharedLabelFilters{OmitPersonal: true},
+	) {
+		if err != nil {
+			t.Fatalf("AllSharedLabels failed: %v", err)
+		}
+		labels = append(labels, label)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+}
@@ -0,0 +1,116 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// optionalState tells apart the three states of an Optional.
+type optionalState int
+
+const (
+	optionalUnset optionalState = iota
+	optionalValue
+	optionalNull
+)
+
+// Optional is a field of an update that can be left unset, set to a value,
+// including the zero value, or set to null. Unset fields are not sent, so the
+// API keeps their current value.
+//
+// Example:
+//
+//	client.UpdateProject(ctx, id, &todoist.ProjectUpdate{
+//	 IsFavorite:  todoist.Some(false),
+//	 Description: todoist.Some(""),
+//	})
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Some returns an Optional set to v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, state: optionalValue}
+}
+
+// Null returns an Optional set to null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// IsSet reports whether the Optional is set, to a value or to null.
+func (o Optional[T]) IsSet() bool {
+	return o.state != optionalUnset
+}
+
+// IsNull reports whether the Optional is set to null.
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// Get returns the value of the Optional and whether it is set to a value.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalValue
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// optionalField is implemented by every Optional, whatever its type.
+type optionalField interface {
+	IsSet() bool
+}
+
+// marshalUpdate encodes a struct of Optional fields as a JSON object holding
+// only the fields that are set. Fields of other types are always encoded,
+// unless they are tagged with omitempty and empty.
+func marshalUpdate(update any) ([]byte, error) {
+	v := reflect.ValueOf(update)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	t := v.Type()
+
+	fields := make(map[string]any, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := v.Field(i)
+		if o, ok := value.Interface().(optionalField); ok {
+			if !o.IsSet() {
+				continue
+			}
+		} else if strings.Contains(opts, "omitempty") && value.IsZero() {
+			continue
+		}
+		fields[name] = value.Interface()
+	}
+	return json.Marshal(fields)
+}
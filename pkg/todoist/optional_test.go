@@ -0,0 +1,83 @@
+package todoist
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOptionalStates(t *testing.T) {
+	var unset Optional[int]
+	if unset.IsSet() || unset.IsNull() {
+		t.Error("zero Optional is set")
+	}
+	if v, ok := Some(0).Get(); !ok || v != 0 {
+		t.Errorf("Some(0).Get() = %d, %t, want 0, true", v, ok)
+	}
+	null := Null[int]()
+	if !null.IsSet() || !null.IsNull() {
+		t.Error("Null() is not set to null")
+	}
+	if _, ok := null.Get(); ok {
+		t.Error("Null().Get() returned a value")
+	}
+}
+
+func TestMarshalUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update TaskUpdate
+		want   string
+	}{
+		{"empty", TaskUpdate{}, `{}`},
+		{
+			"zero values",
+			TaskUpdate{Priority: Some(0), Content: Some(""), Labels: Some([]string{})},
+			`{"content":"","labels":[],"priority":0}`,
+		},
+		{
+			"null",
+			TaskUpdate{AssigneeID: Null[string](), DueString: Null[string]()},
+			`{"assignee_id":null,"due_string":null}`,
+		},
+		{
+			"values",
+			TaskUpdate{DueDateTime: Some("2024-03-10T12:00:00Z")},
+			`{"due_datetime":"2024-03-10T12:00:00Z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&tt.update)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("encoded %s, want %s", data, tt.want)
+			}
+
+			var decoded TaskUpdate
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.update) {
+				t.Errorf("decoded %+v, want %+v", decoded, tt.update)
+			}
+		})
+	}
+}
+
+func TestMarshalUpdateKeepsOtherFields(t *testing.T) {
+	data, err := marshalUpdate(struct {
+		ID      string           `json:"id"`
+		Note    string           `json:"note,omitempty"`
+		Name    Optional[string] `json:"name"`
+		private int
+	}{ID: "1", private: 1})
+	if err != nil {
+		t.Fatalf("marshalUpdate failed: %v", err)
+	}
+	if string(data) != `{"id":"1"}` {
+		t.Errorf("encoded %s, want %s", data, `{"id":"1"}`)
+	}
+}
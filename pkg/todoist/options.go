@@ -0,0 +1,68 @@
+package todoist
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout of the http.Client created by NewClient when
+// no client is provided with WithHTTPClient.
+const DefaultTimeout = 30 * time.Second
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests. This allows
+// connection pools to be shared with the rest of an application.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL sets the base URL of the API, e.g. to point the client at a
+// local test server. The Sync API endpoint is derived from it as well.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout of the http.Client used to send requests. A
+// client provided with WithHTTPClient is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests, e.g. one
+// configured with a proxy. A client provided with WithHTTPClient is copied
+// rather than modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := *c.HTTPClient
+		httpClient.Transport = transport
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests. Passing nil
+// disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
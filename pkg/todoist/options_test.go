@@ -0,0 +1,157 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is an http.RoundTripper calling the function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient("token")
+
+	if c.BaseURL != DefaultBaseURL {
+		t.Errorf("BaseURL = %q, want %q", c.BaseURL, DefaultBaseURL)
+	}
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("HTTPClient = %+v, want timeout %v", c.HTTPClient, DefaultTimeout)
+	}
+	if c.RetryPolicy == nil {
+		t.Error("RetryPolicy = nil, want the default policy")
+	}
+	if c.Sync.APIKey != "token" || c.Sync.client != c {
+		t.Error("Sync is not set up with the client and API key")
+	}
+}
+
+func TestWithBaseURLAndUserAgent(t *testing.T) {
+	var paths, agents []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			agents = append(agents, r.Header.Get("User-Agent"))
+			w.Write([]byte(`{"sync_token":"t","full_sync":true}`))
+		},
+	))
+	t.Cleanup(server.Close)
+
+	c := NewClient(
+		"token",
+		WithBaseURL(server.URL+"/api/v1/"),
+		WithUserAgent("todoist-test/1.0"),
+	)
+	if want := server.URL + "/api/v1"; c.BaseURL != want {
+		t.Errorf("BaseURL = %q, want %q", c.BaseURL, want)
+	}
+
+	res, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	_, err = c.Sync.ReadResources(context.Background(), []string{"items"})
+	if err != nil {
+		t.Fatalf("ReadResources failed: %v", err)
+	}
+
+	want := []string{"/api/v1/tasks", "/api/v1/sync"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	for _, agent := range agents {
+		if agent != "todoist-test/1.0" {
+			t.Errorf("User-Agent = %q, want todoist-test/1.0", agent)
+		}
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	var calls int
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httptest.NewRecorder().Result(), nil
+		}),
+	}
+
+	c := NewClient("token", WithHTTPClient(httpClient))
+	if c.HTTPClient != httpClient {
+		t.Error("HTTPClient is not the given client")
+	}
+	res, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if calls != 1 {
+		t.Errorf("transport called %d times, want 1", calls)
+	}
+
+	c = NewClient("token", WithHTTPClient(nil))
+	if c.HTTPClient == nil {
+		t.Error("WithHTTPClient(nil) removed the default client")
+	}
+}
+
+func TestWithTimeoutAndTransportCopyTheClient(t *testing.T) {
+	var calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httptest.NewRecorder().Result(), nil
+	})
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	c := NewClient(
+		"token",
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithTransport(transport),
+	)
+	if c.HTTPClient == httpClient {
+		t.Error("HTTPClient is the given client, want a copy")
+	}
+	if httpClient.Timeout != time.Minute || httpClient.Transport != nil {
+		t.Errorf("given client was modified: %+v", httpClient)
+	}
+	if c.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", c.HTTPClient.Timeout)
+	}
+
+	res, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if calls != 1 {
+		t.Errorf("transport called %d times, want 1", calls)
+	}
+}
+
+func TestWithRetryPolicyNilDisablesRetries(t *testing.T) {
+	var requests int
+	c := newTestClient(
+		t,
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+		WithRetryPolicy(nil),
+	)
+
+	_, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err == nil {
+		t.Fatal("request succeeded, want an error")
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+}
@@ -1,5 +1,10 @@
 package todoist
 
+import (
+	"context"
+	"iter"
+)
+
 // PaginationFilters is used to specify the page size and cursor for
 // paginated requests. The cursor is used to get the next page of results.
 // If there are no more pages, the cursor will be nil.
@@ -19,7 +24,8 @@ type PaginationFilters struct {
 // The NextCursor and Results fields will usually be returned as separate return
 // values when an endpoint is called. The NextCursor will be nil if there are no
 // more pages to return. The Results field will contain the results of the
-// request.
+// request. To iterate over every page instead, use the matching All method,
+// e.g. AllProjects.
 //
 // Example:
 //
@@ -30,3 +36,68 @@ type PaginationResponse[T any] struct {
 	NextCursor *string `json:"next_cursor"`
 	Results    []T     `json:"results"`
 }
+
+// paginate returns an iterator over every result of a paginated endpoint,
+// starting at the given cursor. fetch is called with the cursor of each page
+// and returns the page results and the next cursor. Iteration stops after the
+// last page, at the first error or when the context is done. Errors are
+// yielded once with the zero value of T.
+func paginate[T any](
+	ctx context.Context,
+	cursor string,
+	fetch func(cursor string) ([]T, *string, error),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			results, nextCursor, err := fetch(cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, result := range results {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(result, nil) {
+					return
+				}
+			}
+
+			if nextCursor == nil || *nextCursor == "" {
+				return
+			}
+			cursor = *nextCursor
+		}
+	}
+}
+
+// CollectAll drains an iterator returned by one of the All methods into a
+// slice. If maxItems is greater than zero, iteration stops once that many
+// items have been collected and no further pages are requested. When the
+// iterator yields an error, the items collected so far are returned along
+// with it.
+//
+// Example:
+//
+//	tasks, err := todoist.CollectAll(client.AllTasks(ctx, nil), 500)
+func CollectAll[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if maxItems > 0 && len(items) == maxItems {
+			break
+		}
+	}
+	return items, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // Project represents a Todoist project.
@@ -13,20 +14,20 @@ import (
 // more logic to identify which types was returned and allows for storing an
 // array of projects without having to worry about the type.
 type Project struct {
-	ID             string  `json:"id"`
-	CanAssignTasks bool    `json:"can_assign_tasks"`
-	ChildOrder     int     `json:"child_order"`
-	Color          string  `json:"color"`
-	CreatedAt      *string `json:"created_at"`
-	IsArchived     bool    `json:"is_archived"`
-	IsDeleted      bool    `json:"is_deleted"`
-	IsFavorite     bool    `json:"is_favorite"`
-	IsFrozen       bool    `json:"is_frozen"`
-	Name           string  `json:"name"`
-	UpdatedAt      *string `json:"updated_at"`
-	ViewStyle      string  `json:"view_style"`
-	DefaultOrder   int     `json:"default_order"`
-	Description    string  `json:"description"`
+	ID             string     `json:"id"`
+	CanAssignTasks bool       `json:"can_assign_tasks"`
+	ChildOrder     int        `json:"child_order"`
+	Color          string     `json:"color"`
+	CreatedAt      *Timestamp `json:"created_at"`
+	IsArchived     bool       `json:"is_archived"`
+	IsDeleted      bool       `json:"is_deleted"`
+	IsFavorite     bool       `json:"is_favorite"`
+	IsFrozen       bool       `json:"is_frozen"`
+	Name           string     `json:"name"`
+	UpdatedAt      *Timestamp `json:"updated_at"`
+	ViewStyle      string     `json:"view_style"`
+	DefaultOrder   int        `json:"default_order"`
+	Description    string     `json:"description"`
 	Access         *struct {
 		Visibility    string `json:"visibility"`
 		Configuration any    `json:"configuration"`
@@ -45,8 +46,7 @@ type Project struct {
 	IsShared     bool    `json:"is_shared"`
 }
 
-// ProjectOptions represents the body parameters for creating or updating a
-// project.
+// ProjectOptions represents the body parameters for creating a project.
 type ProjectOptions struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
@@ -56,6 +56,21 @@ type ProjectOptions struct {
 	ViewStyle   string `json:"view_style,omitempty"`
 }
 
+// ProjectUpdate represents the body parameters for updating a project. Only
+// the fields that are set are updated, so fields can be cleared by setting
+// them to their zero value, e.g. IsFavorite to Some(false).
+type ProjectUpdate struct {
+	Name        Optional[string] `json:"name"`
+	Description Optional[string] `json:"description"`
+	Color       Optional[string] `json:"color"`
+	IsFavorite  Optional[bool]   `json:"is_favorite"`
+	ViewStyle   Optional[string] `json:"view_style"`
+}
+
+func (u ProjectUpdate) MarshalJSON() ([]byte, error) {
+	return marshalUpdate(u)
+}
+
 // GetProjects returns a list containing all active user projects and a cursor
 // for pagination. The cursor is nil if there are no more pages to return.
 func (c *Client) GetProjects(
@@ -76,6 +91,26 @@ func (c *Client) GetProjects(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllProjects returns an iterator over all active user projects, requesting
+// further pages as needed.
+func (c *Client) AllProjects(
+	ctx context.Context,
+	pagination *PaginationFilters,
+) iter.Seq2[Project, error] {
+	var p PaginationFilters
+	if pagination != nil {
+		p = *pagination
+	}
+	return paginate(
+		ctx,
+		p.Cursor,
+		func(cursor string) ([]Project, *string, error) {
+			p.Cursor = cursor
+			return c.GetProjects(ctx, &p)
+		},
+	)
+}
+
 // GetArchived returns a list containing all archived user projects and a cursor
 // for pagination. The cursor is nil if there are no more pages to return.
 func (c *Client) GetArchived(
@@ -96,6 +131,26 @@ func (c *Client) GetArchived(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllArchived returns an iterator over all archived user projects, requesting
+// further pages as needed.
+func (c *Client) AllArchived(
+	ctx context.Context,
+	pagination *PaginationFilters,
+) iter.Seq2[Project, error] {
+	var p PaginationFilters
+	if pagination != nil {
+		p = *pagination
+	}
+	return paginate(
+		ctx,
+		p.Cursor,
+		func(cursor string) ([]Project, *string, error) {
+			p.Cursor = cursor
+			return c.GetArchived(ctx, &p)
+		},
+	)
+}
+
 // CreateProject creates a new project with the given name and options.
 // The name is required, and any additional options can be set in the
 // ProjectOptions
@@ -105,7 +160,7 @@ func (c *Client) CreateProject(
 	options *ProjectOptions,
 ) (*Project, error) {
 	if name == "" {
-		return nil, fmt.Errorf("name is required")
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidArgument)
 	}
 	if options == nil {
 		options = &ProjectOptions{}
@@ -152,18 +207,22 @@ func (c *Client) GetProject(
 	return &project, nil
 }
 
-// UpdateProject updates a project with the given projectId with the given
-// ProjectOptions.
+// UpdateProject updates the fields of the project with the given projectId
+// that are set in update.
 func (c *Client) UpdateProject(
 	ctx context.Context,
 	projectId string,
-	options *ProjectOptions,
+	update *ProjectUpdate,
 ) (*Project, error) {
+	if update == nil {
+		return nil, fmt.Errorf("%w: update is required", ErrInvalidArgument)
+	}
+
 	res, err := c.request(
 		ctx,
 		"POST",
 		fmt.Sprintf("/projects/%s", projectId),
-		options,
+		update,
 		nil,
 	)
 	if err != nil {
@@ -261,5 +320,151 @@ func (c *Client) GetProjectCollaborators(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
-// TODO: Implement Projects Join Endpoint
-// https://developer.todoist.com/api/v1#tag/Projects/operation/join_api_v1_projects__project_id__join_post
+// AllProjectCollaborators returns an iterator over all collaborators of the
+// given projectId, requesting further pages as needed.
+func (c *Client) AllProjectCollaborators(
+	ctx context.Context,
+	projectId string,
+	pagination *PaginationFilters,
+) iter.Seq2[Collaborator, error] {
+	var p PaginationFilters
+	if pagination != nil {
+		p = *pagination
+	}
+	return paginate(
+		ctx,
+		p.Cursor,
+		func(cursor string) ([]Collaborator, *string, error) {
+			p.Cursor = cursor
+			return c.GetProjectCollaborators(ctx, projectId, &p)
+		},
+	)
+}
+
+// ProjectJoinResult holds the project a user joined and its data.
+type ProjectJoinResult struct {
+	Project            Project             `json:"project"`
+	Subprojects        []Project           `json:"subprojects"`
+	Items              []Task              `json:"items"`
+	Sections           []Section           `json:"sections"`
+	ProjectNotes       []Comment           `json:"project_notes"`
+	Collaborators      []Collaborator      `json:"collaborators"`
+	CollaboratorStates []CollaboratorState `json:"collaborator_states"`
+}
+
+// JoinProject joins the workspace project with the given projectId, which
+// the user can see in the workspace but is not a member of, and returns the
+// project with its tasks, sections and collaborators.
+func (c *Client) JoinProject(
+	ctx context.Context,
+	projectId string,
+) (*ProjectJoinResult, error) {
+	if projectId == "" {
+		return nil, fmt.Errorf("%w: project ID is required", ErrInvalidArgument)
+	}
+
+	res, err := c.request(
+		ctx,
+		"POST",
+		fmt.Sprintf("/projects/%s/join", projectId),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join project: %w", err)
+	}
+	defer res.Body.Close()
+
+	var result ProjectJoinResult
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode join project response: %w", err)
+	}
+	return &result, nil
+}
+
+// ProjectDestination is where a project is moved to. Exactly one of ParentID,
+// Root, WorkspaceID or Personal must be set.
+type ProjectDestination struct {
+	ParentID string // Under another project of the same space
+	Root     bool   // To the root level of its current space
+
+	// WorkspaceID moves a personal project into a workspace, optionally into
+	// the folder with FolderID.
+	WorkspaceID string
+	FolderID    string
+
+	Personal bool // From a workspace back to the user's personal projects
+}
+
+// command returns the sync command that moves the project with the given ID
+// to the destination.
+func (d ProjectDestination) command(projectId string) (Command, error) {
+	set := 0
+	for _, ok := range []bool{
+		d.ParentID != "",
+		d.Root,
+		d.WorkspaceID != "",
+		d.Personal,
+	} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return Command{}, fmt.Errorf(
+			"%w: exactly one of parent_id, root, workspace_id or personal "+
+				"must be set",
+			ErrInvalidArgument,
+		)
+	}
+	if d.FolderID != "" && d.WorkspaceID == "" {
+		return Command{}, fmt.Errorf(
+			"%w: folder_id requires workspace_id",
+			ErrInvalidArgument,
+		)
+	}
+
+	switch {
+	case d.WorkspaceID != "":
+		return NewProjectMoveToWorkspaceCommand(ProjectMoveToWorkspaceArgs{
+			ProjectID:   projectId,
+			WorkspaceID: d.WorkspaceID,
+			FolderID:    d.FolderID,
+		}), nil
+	case d.Personal:
+		return NewProjectMoveToPersonalCommand(projectId), nil
+	default:
+		return NewProjectMoveCommand(projectId, d.ParentID), nil
+	}
+}
+
+// MoveProject moves the project with the given projectId, along with its
+// subprojects, under another project, to the root level, into a workspace
+// and folder, or back to the user's personal projects. Projects are moved
+// with sync commands, as the REST API cannot change their parent or space.
+//
+// Example:
+//
+//	err := client.MoveProject(ctx, projectID, todoist.ProjectDestination{
+//	 WorkspaceID: workspaceID,
+//	 FolderID:    folderID,
+//	})
+func (c *Client) MoveProject(
+	ctx context.Context,
+	projectId string,
+	destination ProjectDestination,
+) error {
+	if projectId == "" {
+		return fmt.Errorf("%w: project ID is required", ErrInvalidArgument)
+	}
+	command, err := destination.command(projectId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.Sync.execute(ctx, command); err != nil {
+		return fmt.Errorf("failed to move project: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"testing"
+)
+
+func TestProjectDestinationCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination ProjectDestination
+		wantType    string
+		wantArgs    map[string]any
+		wantErr     bool
+	}{
+		{
+			name:        "parent",
+			destination: ProjectDestination{ParentID: "p2"},
+			wantType:    CommandProjectMove,
+			wantArgs:    map[string]any{"id": "p1", "parent_id": "p2"},
+		},
+		{
+			name:        "root",
+			destination: ProjectDestination{Root: true},
+			wantType:    CommandProjectMove,
+			wantArgs:    map[string]any{"id": "p1", "parent_id": nil},
+		},
+		{
+			name:        "workspace",
+			destination: ProjectDestination{WorkspaceID: "w1"},
+			wantType:    CommandProjectMoveToWorkspace,
+			wantArgs:    map[string]any{"project_id": "p1", "workspace_id": "w1"},
+		},
+		{
+			name: "workspace folder",
+			destination: ProjectDestination{
+				WorkspaceID: "w1",
+				FolderID:    "f1",
+			},
+			wantType: CommandProjectMoveToWorkspace,
+			wantArgs: map[string]any{
+				"project_id":   "p1",
+				"workspace_id": "w1",
+				"folder_id":    "f1",
+			},
+		},
+		{
+			name:        "personal",
+			destination: ProjectDestination{Personal: true},
+			wantType:    CommandProjectMoveToPersonal,
+			wantArgs:    map[string]any{"project_id": "p1"},
+		},
+		{
+			name:        "none",
+			destination: ProjectDestination{},
+			wantErr:     true,
+		},
+		{
+			name:        "parent and root",
+			destination: ProjectDestination{ParentID: "p2", Root: true},
+			wantErr:     true,
+		},
+		{
+			name: "workspace and personal",
+			destination: ProjectDestination{
+				WorkspaceID: "w1",
+				Personal:    true,
+			},
+			wantErr: true,
+		},
+		{
+			name:        "folder without workspace",
+			destination: ProjectDestination{Root: true, FolderID: "f1"},
+			wantErr:     true,
+		},
+		{
+			name:        "only folder",
+			destination: ProjectDestination{FolderID: "f1"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := tt.destination.command("p1")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("error = %v, want ErrInvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command failed: %v", err)
+			}
+			if command.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", command.Type, tt.wantType)
+			}
+			if !maps.Equal(command.Args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", command.Args, tt.wantArgs)
+			}
+			if command.UUID == "" {
+				t.Error("UUID is empty")
+			}
+		})
+	}
+}
+
+func TestMoveProjectSendsCommand(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	err := c.MoveProject(context.Background(), "p1", ProjectDestination{
+		WorkspaceID: "w1",
+		FolderID:    "f1",
+	})
+	if err != nil {
+		t.Fatalf("MoveProject failed: %v", err)
+	}
+	if len(server.requests) != 1 || len(server.requests[0]) != 1 {
+		t.Fatalf("requests = %v, want one command", server.requests)
+	}
+	command := server.requests[0][0]
+	if command.Type != CommandProjectMoveToWorkspace {
+		t.Errorf("type = %q, want %q", command.Type, CommandProjectMoveToWorkspace)
+	}
+	if command.Args["folder_id"] != "f1" {
+		t.Errorf("folder_id = %v, want f1", command.Args["folder_id"])
+	}
+}
+
+func TestMoveProjectValidatesBeforeSending(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	for _, tt := range []struct {
+		projectId   string
+		destination ProjectDestination
+	}{
+		{"", ProjectDestination{Root: true}},
+		{"p1", ProjectDestination{}},
+	} {
+		err := c.MoveProject(context.Background(), tt.projectId, tt.destination)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf(
+				"MoveProject(%q, %+v) error = %v, want ErrInvalidArgument",
+				tt.projectId,
+				tt.destination,
+				err,
+			)
+		}
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(server.requests))
+	}
+}
+
+func TestMoveProjectReturnsCommandError(t *testing.T) {
+	server := &syncServer{fail: []string{CommandProjectMove}}
+	c := newSyncTestClient(t, server)
+
+	err := c.MoveProject(
+		context.Background(),
+		"p1",
+		ProjectDestination{ParentID: "p2"},
+	)
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) {
+		t.Fatalf("error = %v, want a CommandError", err)
+	}
+}
+
+func TestJoinProjectDecodesResult(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/projects/p1/join" {
+			t.Errorf("request = %s %s, want POST /projects/p1/join",
+				r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{
+			"project": {"id": "p1", "name": "Team"},
+			"subprojects": [{"id": "p2", "name": "Sub", "parent_id": "p1"}],
+			"items": [{"id": "t1", "content": "Task", "project_id": "p1"}],
+			"sections": [{"id": "s1", "name": "Doing", "project_id": "p1"}],
+			"project_notes": [{"id": "n1", "content": "Note"}],
+			"collaborators": [{"id": "u1", "name": "Jane"}],
+			"collaborator_states": [{"project_id": "p1", "user_id": "u1", "state": "active"}]
+		}`)
+	})
+
+	result, err := c.JoinProject(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("JoinProject failed: %v", err)
+	}
+	if result.Project.ID != "p1" || result.Project.Name != "Team" {
+		t.Errorf("project = %+v, want p1 Team", result.Project)
+	}
+	if len(result.Subprojects) != 1 || result.Subprojects[0].ID != "p2" {
+		t.Errorf("subprojects = %+v, want p2", result.Subprojects)
+	}
+	if len(result.Items) != 1 || result.Items[0].ID != "t1" {
+		t.Errorf("items = %+v, want t1", result.Items)
+	}
+	if len(result.Sections) != 1 || result.Sections[0].ID != "s1" {
+		t.Errorf("sections = %+v, want s1", result.Sections)
+	}
+	if len(result.ProjectNotes) != 1 || result.ProjectNotes[0].ID != "n1" {
+		t.Errorf("project notes = %+v, want n1", result.ProjectNotes)
+	}
+	if len(result.Collaborators) != 1 || result.Collaborators[0].ID != "u1" {
+		t.Errorf("collaborators = %+v, want u1", result.Collaborators)
+	}
+	if len(result.CollaboratorStates) != 1 ||
+		result.CollaboratorStates[0].UserID != "u1" {
+		t.Errorf(
+			"collaborator states = %+v, want u1",
+			result.CollaboratorStates,
+		)
+	}
+}
+
+func TestJoinProjectRequiresID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	_, err := c.JoinProject(context.Background(), "")
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("error = %v, want ErrInvalidArgument", err)
+	}
+}
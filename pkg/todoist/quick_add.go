@@ -0,0 +1,177 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// QuickAddOptions represents the optional body parameters of QuickAddTask.
+type QuickAddOptions struct {
+	Note         string `json:"note,omitempty"`     // Content of a comment added to the task
+	Reminder     string `json:"reminder,omitempty"` // Date of a reminder in natural language
+	AutoReminder bool   `json:"auto_reminder,omitempty"`
+	Meta         bool   `json:"meta,omitempty"` // Return how the text was parsed in QuickAddResult.Meta
+}
+
+// QuickAddResult is the task created by QuickAddTask, along with how its text
+// was interpreted when QuickAddOptions.Meta is set.
+type QuickAddResult struct {
+	Task
+	Meta *QuickAddMeta `json:"meta,omitempty"`
+}
+
+// QuickAddRef is a resource referred to in quick add text, such as the
+// project of "#Work" or the assignee of "+Jane".
+type QuickAddRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// QuickAddMeta describes how the quick add text was interpreted. Fields that
+// were not found in the text are nil or empty. Raw holds the metadata as
+// returned by the API.
+type QuickAddMeta struct {
+	Content  string
+	Project  *QuickAddRef
+	Section  *QuickAddRef
+	Assignee *QuickAddRef
+	Labels   []QuickAddRef
+	Due      *Due
+	Deadline *Deadline
+	Raw      json.RawMessage
+}
+
+// UnmarshalJSON decodes the metadata leniently: references can be sent as an
+// object, an [id, name] pair or a bare ID, and labels as a list or as a map of
+// IDs to names. Parts that cannot be understood are left empty and can still
+// be read from Raw.
+func (m *QuickAddMeta) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("failed to decode quick add meta: %w", err)
+	}
+
+	*m = QuickAddMeta{Raw: append(json.RawMessage(nil), data...)}
+	_ = json.Unmarshal(fields["content"], &m.Content)
+	m.Project = parseQuickAddRef(fields["project"])
+	m.Section = parseQuickAddRef(fields["section"])
+	m.Assignee = parseQuickAddRef(fields["assignee"])
+	m.Labels = parseQuickAddRefs(fields["labels"])
+	if isJSONValue(fields["due"]) {
+		var due Due
+		if json.Unmarshal(fields["due"], &due) == nil {
+			m.Due = &due
+		}
+	}
+	if isJSONValue(fields["deadline"]) {
+		var deadline Deadline
+		if json.Unmarshal(fields["deadline"], &deadline) == nil {
+			m.Deadline = &deadline
+		}
+	}
+	return nil
+}
+
+func (m QuickAddMeta) MarshalJSON() ([]byte, error) {
+	if m.Raw != nil {
+		return m.Raw, nil
+	}
+	return json.Marshal(struct {
+		Content  string        `json:"content,omitempty"`
+		Project  *QuickAddRef  `json:"project,omitempty"`
+		Section  *QuickAddRef  `json:"section,omitempty"`
+		Assignee *QuickAddRef  `json:"assignee,omitempty"`
+		Labels   []QuickAddRef `json:"labels,omitempty"`
+		Due      *Due          `json:"due,omitempty"`
+		Deadline *Deadline     `json:"deadline,omitempty"`
+	}{m.Content, m.Project, m.Section, m.Assignee, m.Labels, m.Due, m.Deadline})
+}
+
+// isJSONValue reports whether data holds a value other than null.
+func isJSONValue(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && !bytes.Equal(data, []byte("null"))
+}
+
+// parseQuickAddRef decodes a reference sent as an object, an [id, name] pair
+// or a bare ID. It returns nil if data is empty or not understood.
+func parseQuickAddRef(data json.RawMessage) *QuickAddRef {
+	if !isJSONValue(data) {
+		return nil
+	}
+
+	var v any
+	if json.Unmarshal(data, &v) != nil {
+		return nil
+	}
+
+	var ref QuickAddRef
+	switch v := v.(type) {
+	case map[string]any:
+		ref = QuickAddRef{ID: anyToString(v["id"]), Name: anyToString(v["name"])}
+	case []any:
+		if len(v) > 0 {
+			ref.ID = anyToString(v[0])
+		}
+		if len(v) > 1 {
+			ref.Name = anyToString(v[1])
+		}
+	case string, float64:
+		ref.ID = anyToString(v)
+	}
+	if ref.ID == "" {
+		return nil
+	}
+	return &ref
+}
+
+// parseQuickAddRefs decodes a list of references, or a map of IDs to names
+// sorted by ID.
+func parseQuickAddRefs(data json.RawMessage) []QuickAddRef {
+	if !isJSONValue(data) {
+		return nil
+	}
+
+	var byID map[string]any
+	if json.Unmarshal(data, &byID) == nil {
+		refs := make([]QuickAddRef, 0, len(byID))
+		for _, id := range slices.Sorted(maps.Keys(byID)) {
+			refs = append(refs, QuickAddRef{ID: id, Name: anyToString(byID[id])})
+		}
+		return refs
+	}
+
+	var list []json.RawMessage
+	if json.Unmarshal(data, &list) != nil {
+		return nil
+	}
+	var refs []QuickAddRef
+	for _, item := range list {
+		var name string
+		if json.Unmarshal(item, &name) == nil {
+			// Labels are usually referred to by name only.
+			refs = append(refs, QuickAddRef{Name: name})
+			continue
+		}
+		if ref := parseQuickAddRef(item); ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// anyToString formats a decoded JSON string or number as a string, and any
+// other value as an empty string. IDs are sent as strings by the current API
+// and as numbers by older versions.
+func anyToString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	}
+	return ""
+}
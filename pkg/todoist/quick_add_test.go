@@ -0,0 +1,225 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseQuickAddRef(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *QuickAddRef
+	}{
+		{"object", `{"id":"p1","name":"Work"}`, &QuickAddRef{"p1", "Work"}},
+		{"object without name", `{"id":"p1"}`, &QuickAddRef{ID: "p1"}},
+		{"numeric object ID", `{"id":42,"name":"Work"}`, &QuickAddRef{"42", "Work"}},
+		{"pair", `["p1","Work"]`, &QuickAddRef{"p1", "Work"}},
+		{"numeric pair", `[42,"Work"]`, &QuickAddRef{"42", "Work"}},
+		{"single item list", `["p1"]`, &QuickAddRef{ID: "p1"}},
+		{"bare ID", `"p1"`, &QuickAddRef{ID: "p1"}},
+		{"bare numeric ID", `2203306141`, &QuickAddRef{ID: "2203306141"}},
+		{"missing", ``, nil},
+		{"null", `null`, nil},
+		{"empty list", `[]`, nil},
+		{"object without ID", `{"name":"Work"}`, nil},
+		{"boolean", `true`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseQuickAddRef(json.RawMessage(tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseQuickAddRef(%s) = %+v, want %+v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []QuickAddRef
+	}{
+		{
+			"map of IDs to names",
+			`{"l2":"errand","l1":"home"}`,
+			[]QuickAddRef{{"l1", "home"}, {"l2", "errand"}},
+		},
+		{
+			"names",
+			`["home","errand"]`,
+			[]QuickAddRef{{Name: "home"}, {Name: "errand"}},
+		},
+		{
+			"objects",
+			`[{"id":"l1","name":"home"},{"id":"l2"}]`,
+			[]QuickAddRef{{"l1", "home"}, {ID: "l2"}},
+		},
+		{
+			"pairs",
+			`[["l1","home"],[2,"errand"]]`,
+			[]QuickAddRef{{"l1", "home"}, {"2", "errand"}},
+		},
+		{
+			"unknown items skipped",
+			`["home",true,{"name":"x"}]`,
+			[]QuickAddRef{{Name: "home"}},
+		},
+		{"missing", ``, nil},
+		{"null", `null`, nil},
+		{"not a list", `"home"`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseQuickAddRefs(json.RawMessage(tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseQuickAddRefs(%s) = %+v, want %+v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuickAddMetaUnmarshal(t *testing.T) {
+	data := `{
+		"content": "Buy milk",
+		"project": ["p1", "Errands"],
+		"section": {"id": "s1", "name": "Shop"},
+		"assignee": 12345,
+		"labels": {"l1": "home"},
+		"due": {"date": "2025-01-31", "string": "tomorrow", "lang": "en", "is_recurring": false},
+		"deadline": {"date": "2025-02-07"},
+		"extra": true
+	}`
+
+	var meta QuickAddMeta
+	if err := json.Unmarshal([]byte(data), &meta); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if meta.Content != "Buy milk" {
+		t.Errorf("Content = %q, want %q", meta.Content, "Buy milk")
+	}
+	if want := (&QuickAddRef{"p1", "Errands"}); !reflect.DeepEqual(meta.Project, want) {
+		t.Errorf("Project = %+v, want %+v", meta.Project, want)
+	}
+	if want := (&QuickAddRef{"s1", "Shop"}); !reflect.DeepEqual(meta.Section, want) {
+		t.Errorf("Section = %+v, want %+v", meta.Section, want)
+	}
+	if want := (&QuickAddRef{ID: "12345"}); !reflect.DeepEqual(meta.Assignee, want) {
+		t.Errorf("Assignee = %+v, want %+v", meta.Assignee, want)
+	}
+	if want := []QuickAddRef{{"l1", "home"}}; !reflect.DeepEqual(meta.Labels, want) {
+		t.Errorf("Labels = %+v, want %+v", meta.Labels, want)
+	}
+	if meta.Due == nil || meta.Due.Date != "2025-01-31" || meta.Due.String != "tomorrow" {
+		t.Errorf("Due = %+v, want date 2025-01-31 and string tomorrow", meta.Due)
+	}
+	if meta.Deadline == nil || meta.Deadline.Date != "2025-02-07" {
+		t.Errorf("Deadline = %+v, want date 2025-02-07", meta.Deadline)
+	}
+	if string(meta.Raw) != data {
+		t.Errorf("Raw = %s, want the input", meta.Raw)
+	}
+
+	out, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, meta.Raw); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if string(out) != want.String() {
+		t.Errorf("Marshal = %s, want %s", out, want.String())
+	}
+}
+
+func TestQuickAddMetaUnmarshalEmptyFields(t *testing.T) {
+	data := `{"content":"Buy milk","project":null,"section":null,` +
+		`"assignee":null,"labels":[],"due":null,"deadline":null}`
+
+	var meta QuickAddMeta
+	if err := json.Unmarshal([]byte(data), &meta); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if meta.Project != nil || meta.Section != nil || meta.Assignee != nil {
+		t.Errorf("refs = %+v %+v %+v, want nil",
+			meta.Project, meta.Section, meta.Assignee)
+	}
+	if len(meta.Labels) != 0 {
+		t.Errorf("Labels = %+v, want none", meta.Labels)
+	}
+	if meta.Due != nil || meta.Deadline != nil {
+		t.Errorf("Due = %+v, Deadline = %+v, want nil", meta.Due, meta.Deadline)
+	}
+}
+
+func TestQuickAddMetaUnmarshalRejectsNonObject(t *testing.T) {
+	var meta QuickAddMeta
+	if err := json.Unmarshal([]byte(`["p1"]`), &meta); err == nil {
+		t.Error("Unmarshal succeeded, want an error")
+	}
+}
+
+func TestQuickAddMetaMarshalWithoutRaw(t *testing.T) {
+	meta := QuickAddMeta{
+		Content: "Buy milk",
+		Project: &QuickAddRef{ID: "p1", Name: "Errands"},
+		Labels:  []QuickAddRef{{Name: "home"}},
+	}
+	out, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"content":"Buy milk","project":{"id":"p1","name":"Errands"},` +
+		`"labels":[{"id":"","name":"home"}]}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestQuickAddTaskDecodesMeta(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks/quick" {
+			t.Errorf("path = %q, want /tasks/quick", r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body["text"] != "Buy milk #Errands @home" || body["meta"] != true {
+			t.Errorf("body = %v, want text and meta", body)
+		}
+		fmt.Fprint(w, `{"id":"t1","content":"Buy milk","project_id":"p1",`+
+			`"meta":{"content":"Buy milk","project":["p1","Errands"],`+
+			`"labels":{"l1":"home"}}}`)
+	})
+
+	result, err := c.QuickAddTask(
+		context.Background(),
+		"Buy milk #Errands @home",
+		&QuickAddOptions{Meta: true},
+	)
+	if err != nil {
+		t.Fatalf("QuickAddTask failed: %v", err)
+	}
+	if result.ID != "t1" || result.ProjectID != "p1" {
+		t.Errorf("task = %s in %s, want t1 in p1", result.ID, result.ProjectID)
+	}
+	if result.Meta == nil {
+		t.Fatal("Meta = nil, want the parsed metadata")
+	}
+	if want := (&QuickAddRef{"p1", "Errands"}); !reflect.DeepEqual(result.Meta.Project, want) {
+		t.Errorf("Meta.Project = %+v, want %+v", result.Meta.Project, want)
+	}
+	if want := []QuickAddRef{{"l1", "home"}}; !reflect.DeepEqual(result.Meta.Labels, want) {
+		t.Errorf("Meta.Labels = %+v, want %+v", result.Meta.Labels, want)
+	}
+}
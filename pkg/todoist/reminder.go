@@ -1,22 +1,325 @@
 package todoist
 
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ReminderType is the kind of a reminder.
+type ReminderType string
+
+const (
+	ReminderRelative ReminderType = "relative" // Some minutes before the task is due
+	ReminderAbsolute ReminderType = "absolute" // At a given date and time
+	ReminderLocation ReminderType = "location" // When arriving at or leaving a place
+)
+
+// Triggers of location reminders.
+const (
+	LocationTriggerEnter = "on_enter"
+	LocationTriggerLeave = "on_leave"
+)
+
+// ReminderDue is the date of a reminder. It has the same format as the due
+// date of a task.
+type ReminderDue = Due
+
+// Reminder is a reminder of a task. The REST API has no reminder endpoints, so
+// reminders are read and managed through the Sync API only.
 type Reminder struct {
-	ID        string `json:"id"`
-	NotifyUID string `json:"notify_uid"`
-	ItemID    string `json:"item_id"`
-	Type      string `json:"type"`
-	Due       struct {
-		Date        string  `json:"date"`
-		Timezone    *string `json:"timezone"`
-		IsRecurring bool    `json:"is_recurring"`
-		String      string  `json:"string"`
-		Lang        string  `json:"lang"`
-	} `json:"due"`
-	MinuteOffset int     `json:"minute_offset"`
-	Name         *string `json:"name,omitempty"`
-	LocLat       *string `json:"loc_lat,omitempty"`
-	LocLong      *string `json:"loc_long,omitempty"`
-	LocTrigger   *string `json:"loc_trigger,omitempty"`
-	Radius       *int    `json:"radius,omitempty"`
-	IsDeleted    bool    `json:"is_deleted"`
+	ID           string       `json:"id"`
+	NotifyUID    string       `json:"notify_uid"`
+	ItemID       string       `json:"item_id"`
+	Type         ReminderType `json:"type"`
+	Due          *ReminderDue `json:"due,omitempty"`
+	MinuteOffset int          `json:"minute_offset"`
+	Name         *string      `json:"name,omitempty"`
+	LocLat       *string      `json:"loc_lat,omitempty"`
+	LocLong      *string      `json:"loc_long,omitempty"`
+	LocTrigger   *string      `json:"loc_trigger,omitempty"`
+	Radius       *int         `json:"radius,omitempty"`
+	IsDeleted    bool         `json:"is_deleted"`
+}
+
+// NewRelativeReminder returns the arguments of a reminder sent minuteOffset
+// minutes before the task is due. The task must have a due date with a time.
+func NewRelativeReminder(itemID string, minuteOffset int) ReminderAddArgs {
+	return ReminderAddArgs{
+		ItemID:       itemID,
+		Type:         ReminderRelative,
+		MinuteOffset: &minuteOffset,
+	}
+}
+
+// NewAbsoluteReminder returns the arguments of a reminder sent at the given
+// date and time.
+func NewAbsoluteReminder(itemID string, due DueArgs) ReminderAddArgs {
+	return ReminderAddArgs{
+		ItemID: itemID,
+		Type:   ReminderAbsolute,
+		Due:    &due,
+	}
+}
+
+// LocationArgs describes the place of a location reminder. Trigger is
+// LocationTriggerEnter or LocationTriggerLeave.
+type LocationArgs struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Trigger   string
+	Radius    int // In meters
+}
+
+// NewLocationReminder returns the arguments of a reminder sent when arriving
+// at or leaving a place.
+func NewLocationReminder(itemID string, location LocationArgs) ReminderAddArgs {
+	return ReminderAddArgs{
+		ItemID:     itemID,
+		Type:       ReminderLocation,
+		Name:       location.Name,
+		LocLat:     strconv.FormatFloat(location.Latitude, 'f', -1, 64),
+		LocLong:    strconv.FormatFloat(location.Longitude, 'f', -1, 64),
+		LocTrigger: location.Trigger,
+		Radius:     location.Radius,
+	}
+}
+
+// Validate checks that the arguments hold the fields required by the type of
+// the reminder.
+func (a ReminderAddArgs) Validate() error {
+	if a.ItemID == "" {
+		return fmt.Errorf("%w: item_id is required", ErrInvalidArgument)
+	}
+
+	switch a.Type {
+	case ReminderRelative:
+		if a.MinuteOffset == nil || *a.MinuteOffset < 0 {
+			return fmt.Errorf(
+				"%w: relative reminders need a minute_offset of 0 or more",
+				ErrInvalidArgument,
+			)
+		}
+	case ReminderAbsolute:
+		if a.Due == nil || (a.Due.Date == "" && a.Due.String == "") {
+			return fmt.Errorf(
+				"%w: absolute reminders need a due date",
+				ErrInvalidArgument,
+			)
+		}
+	case ReminderLocation:
+		if a.Name == "" || a.LocLat == "" || a.LocLong == "" {
+			return fmt.Errorf(
+				"%w: location reminders need a name, loc_lat and loc_long",
+				ErrInvalidArgument,
+			)
+		}
+		if err := validateCoordinates(a.LocLat, a.LocLong); err != nil {
+			return err
+		}
+		if a.LocTrigger != LocationTriggerEnter &&
+			a.LocTrigger != LocationTriggerLeave {
+			return fmt.Errorf(
+				"%w: loc_trigger must be %q or %q",
+				ErrInvalidArgument,
+				LocationTriggerEnter,
+				LocationTriggerLeave,
+			)
+		}
+		if a.Radius < 0 {
+			return fmt.Errorf("%w: radius cannot be negative", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf(
+			"%w: unknown reminder type %q",
+			ErrInvalidArgument,
+			a.Type,
+		)
+	}
+	return nil
+}
+
+// validateCoordinates checks that a latitude and longitude are numbers in
+// range.
+func validateCoordinates(lat string, long string) error {
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("%w: invalid latitude %q", ErrInvalidArgument, lat)
+	}
+	longitude, err := strconv.ParseFloat(long, 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("%w: invalid longitude %q", ErrInvalidArgument, long)
+	}
+	return nil
+}
+
+// CheckReminderLimits checks that a task can get one more reminder of the
+// given type under the limits of a plan. existing are the current reminders
+// of the task. Time based reminders, relative and absolute, count against
+// MaxRemindersTime, and location reminders against MaxRemindersLocation.
+// Errors wrap ErrPlanLimitExceeded.
+func CheckReminderLimits(
+	plan UserPlanInfo,
+	existing []Reminder,
+	reminderType ReminderType,
+) error {
+	if !plan.Reminders {
+		return fmt.Errorf(
+			"%w: reminders are not available on the %s plan",
+			ErrPlanLimitExceeded,
+			plan.PlanName,
+		)
+	}
+
+	var timeCount, locationCount int
+	for _, reminder := range existing {
+		switch {
+		case reminder.IsDeleted:
+		case reminder.Type == ReminderLocation:
+			locationCount++
+		default:
+			timeCount++
+		}
+	}
+
+	if reminderType == ReminderLocation {
+		if locationCount >= plan.MaxRemindersLocation {
+			return fmt.Errorf(
+				"%w: at most %d location reminders per task",
+				ErrPlanLimitExceeded,
+				plan.MaxRemindersLocation,
+			)
+		}
+		return nil
+	}
+	if timeCount >= plan.MaxRemindersTime {
+		return fmt.Errorf(
+			"%w: at most %d time reminders per task",
+			ErrPlanLimitExceeded,
+			plan.MaxRemindersTime,
+		)
+	}
+	return nil
+}
+
+// CheckReminderLimits checks that the reminder described by args can be
+// added under the synced plan limits of the user, as CheckReminderLimits does.
+// It returns an error wrapping ErrInvalidArgument if the plan limits have not
+// been synced.
+func (st *Store) CheckReminderLimits(args ReminderAddArgs) error {
+	limits := st.UserPlanLimits()
+	if limits == nil {
+		return fmt.Errorf(
+			"%w: plan limits are unknown, sync user_plan_limits first",
+			ErrInvalidArgument,
+		)
+	}
+	return CheckReminderLimits(
+		limits.Current,
+		st.RemindersByTask(args.ItemID),
+		args.Type,
+	)
+}
+
+// GetReminders returns all active reminders. Reminders are only available
+// through the Sync API, so this performs a full sync of the reminders without
+// changing the SyncToken of client.Sync.
+func (c *Client) GetReminders(ctx context.Context) ([]Reminder, error) {
+	resp, err := c.Sync.readAll(ctx, "reminders")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminders: %w", err)
+	}
+
+	var reminders []Reminder
+	for _, reminder := range resp.Reminders {
+		if !reminder.IsDeleted {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+// CreateReminder validates args, checks the limits of the user's plan and
+// creates the reminder with a reminder_add command, returning its ID. The
+// limits are checked against st as Store.CheckReminderLimits does, or, when st
+// is nil, against the reminders and plan limits fetched from the API. That
+// fetch is a full sync of the reminders on every call, so pass a Store that is
+// kept up to date when creating many reminders.
+func (c *Client) CreateReminder(
+	ctx context.Context,
+	args ReminderAddArgs,
+	st *Store,
+) (string, error) {
+	if err := args.Validate(); err != nil {
+		return "", err
+	}
+	if st != nil {
+		if err := st.CheckReminderLimits(args); err != nil {
+			return "", err
+		}
+	} else if err := c.checkReminderLimits(ctx, args); err != nil {
+		return "", err
+	}
+
+	command := NewReminderAddCommand(args)
+	result, err := c.Sync.execute(ctx, command)
+	if err != nil {
+		return "", fmt.Errorf("failed to create reminder: %w", err)
+	}
+	return result.TempIDMapping[command.TempID], nil
+}
+
+// checkReminderLimits fetches the reminders and plan limits of the user and
+// checks that the reminder described by args can be added.
+func (c *Client) checkReminderLimits(
+	ctx context.Context,
+	args ReminderAddArgs,
+) error {
+	resp, err := c.Sync.readAll(ctx, "reminders", "user_plan_limits")
+	if err != nil {
+		return fmt.Errorf("failed to get reminders: %w", err)
+	}
+
+	var existing []Reminder
+	for _, reminder := range resp.Reminders {
+		if reminder.ItemID == args.ItemID {
+			existing = append(existing, reminder)
+		}
+	}
+	return CheckReminderLimits(resp.UserPlanLimits.Current, existing, args.Type)
+}
+
+// UpdateReminder updates the fields of a reminder that are not nil in args.
+func (c *Client) UpdateReminder(
+	ctx context.Context,
+	args ReminderUpdateArgs,
+) error {
+	if args.ID == "" {
+		return fmt.Errorf("%w: reminder ID is required", ErrInvalidArgument)
+	}
+	if args.LocLat != nil && args.LocLong != nil {
+		if err := validateCoordinates(*args.LocLat, *args.LocLong); err != nil {
+			return err
+		}
+	}
+
+	_, err := c.Sync.execute(ctx, NewReminderUpdateCommand(args))
+	if err != nil {
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+	return nil
+}
+
+// DeleteReminder deletes the reminder with the given ID.
+func (c *Client) DeleteReminder(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: reminder ID is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewReminderDeleteCommand(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	return nil
 }
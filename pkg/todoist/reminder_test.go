@@ -0,0 +1,87 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// newReminderTestClient returns a client whose reads return reminders and
+// plan limits allowing at most one time reminder per task.
+func newReminderTestClient(
+	t *testing.T,
+	server *syncServer,
+	reminders []Reminder,
+) *Client {
+	server.t = t
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("commands") != "" {
+			server.ServeHTTP(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(SyncReadResponse{
+			SyncToken: "token",
+			FullSync:  true,
+			Reminders: reminders,
+			UserPlanLimits: UserPlanLimits{Current: UserPlanInfo{
+				PlanName:             "free",
+				Reminders:            true,
+				MaxRemindersTime:     1,
+				MaxRemindersLocation: 1,
+			}},
+		})
+	})
+}
+
+func TestCreateReminderChecksFetchedLimits(t *testing.T) {
+	server := &syncServer{}
+	c := newReminderTestClient(t, server, []Reminder{
+		{ID: "1", ItemID: "task", Type: ReminderRelative},
+		{ID: "2", ItemID: "other", Type: ReminderRelative},
+	})
+
+	_, err := c.CreateReminder(
+		context.Background(),
+		NewRelativeReminder("task", 30),
+		nil,
+	)
+	if !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("err = %v, want ErrPlanLimitExceeded", err)
+	}
+
+	args := NewRelativeReminder("new", 30)
+	id, err := c.CreateReminder(context.Background(), args, nil)
+	if err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+	if len(server.requests) != 1 || id != "real-"+server.requests[0][0].TempID {
+		t.Errorf("id = %q, want the real ID of the created reminder", id)
+	}
+}
+
+func TestCreateReminderChecksStoreLimits(t *testing.T) {
+	server := &syncServer{}
+	c := newReminderTestClient(t, server, nil)
+	args := NewRelativeReminder("task", 30)
+
+	_, err := c.CreateReminder(context.Background(), args, NewStore())
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("err = %v, want ErrInvalidArgument for unknown limits", err)
+	}
+
+	st := NewStore()
+	resp, err := c.Sync.readAll(context.Background(), "all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Apply(resp)
+	if _, err := c.CreateReminder(context.Background(), args, st); err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+	if len(server.requests) != 1 {
+		t.Errorf("sent %d writes, want 1", len(server.requests))
+	}
+}
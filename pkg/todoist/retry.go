@@ -0,0 +1,176 @@
+package todoist
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Requests are retried
+// when the transport fails or when the response status is one of
+// RetryableStatuses, as long as the request method is one of
+// RetryableMethods or the request carries an X-Request-Id header. Todoist uses
+// the request ID to discard duplicates, which makes those requests safe to
+// send more than once.
+//
+// The delay before attempt n is InitialBackoff * Multiplier^(n-1), capped at
+// MaxBackoff and reduced by a random fraction of up to Jitter. A Retry-After
+// header on the response takes precedence over the computed delay, but is
+// capped at MaxBackoff as well, so that a server cannot stall the caller.
+type RetryPolicy struct {
+	MaxAttempts       int           // Total attempts including the first one
+	InitialBackoff    time.Duration // Delay before the first retry
+	MaxBackoff        time.Duration // Upper bound for the computed delay
+	Multiplier        float64       // Growth factor applied after every retry
+	Jitter            float64       // Fraction of the delay to randomize, 0 to 1
+	RetryableMethods  []string
+	RetryableStatuses []int
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient. It makes up to four
+// attempts and retries rate limiting and transient server errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		RetryableStatuses: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// canRetry reports whether req may be sent more than once under the policy.
+// Requests with a body can only be retried when the body can be rewound.
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	return slices.Contains(p.RetryableMethods, req.Method) ||
+		req.Header.Get("X-Request-Id") != ""
+}
+
+// backoff returns the delay before the given retry attempt, starting at 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// doWithRetry sends req with client, retrying according to policy. The
+// request body is rewound through req.GetBody before every retry. The last
+// response is returned as-is, so callers still see the failing status when
+// the attempts are exhausted.
+func doWithRetry(
+	client *http.Client,
+	policy *RetryPolicy,
+	req *http.Request,
+) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := policy.canRetry(req)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := client.Do(req)
+		if !retryable || attempt >= policy.MaxAttempts {
+			return res, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			delay = policy.backoff(attempt)
+		case slices.Contains(policy.RetryableStatuses, res.StatusCode):
+			delay = policy.backoff(attempt)
+			if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+				if policy.MaxBackoff > 0 {
+					delay = min(delay, policy.MaxBackoff)
+				}
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		default:
+			return res, nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "{}")
+	})
+	// The computed backoff would block the test, so finishing shows that the
+	// Retry-After header was used instead.
+	c.RetryPolicy.InitialBackoff = time.Hour
+	c.RetryPolicy.MaxBackoff = time.Hour
+
+	res, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestRetryAfterIsCappedAtMaxBackoff(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "{}")
+	})
+
+	start := time.Now()
+	res, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, want at most MaxBackoff", elapsed)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestRetryServerErrorThenSuccess(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		io.WriteString(w, `{"id":"1"}`)
+	})
+
+	task, err := c.GetTask(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if task.ID != "1" {
+		t.Errorf("task.ID = %q, want %q", task.ID, "1")
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestRetryReplaysPostBody(t *testing.T) {
+	var bodies, requestIDs []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-Id"))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "{}")
+	})
+
+	res, err := c.request(
+		context.Background(),
+		"POST",
+		"/tasks",
+		map[string]string{"content": "Milk"},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	res.Body.Close()
+
+	if len(bodies) != 2 {
+		t.Fatalf("attempts = %d, want 2", len(bodies))
+	}
+	want := `{"content":"Milk"}`
+	for i, body := range bodies {
+		if body != want {
+			t.Errorf("body of attempt %d = %q, want %q", i+1, body, want)
+		}
+	}
+	if requestIDs[0] == "" || requestIDs[0] != requestIDs[1] {
+		t.Errorf("X-Request-Id = %q, want the same ID on every attempt", requestIDs)
+	}
+}
+
+func TestRetryStopsWhenContextIsCanceled(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.RetryPolicy.InitialBackoff = time.Hour
+	c.RetryPolicy.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.request(ctx, "GET", "/tasks", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v after the context was done", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c.RetryPolicy.MaxAttempts = 3
+
+	_, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("StatusCode = %d, want 500", apiErr.StatusCode)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := c.request(context.Background(), "GET", "/tasks", nil, nil)
+	if err == nil {
+		t.Fatal("err = nil, want an error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", 0, true}, // In the past
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf(
+				"parseRetryAfter(%q) = %v, %v, want %v, %v",
+				tt.value,
+				got,
+				ok,
+				tt.want,
+				tt.ok,
+			)
+		}
+	}
+}
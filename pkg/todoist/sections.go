@@ -1,25 +1,28 @@
 package todoist
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 )
 
 // Section represents a section in Todoist. A section will always belong to a
 // project. Sections are used to organize tasks within a project.
 type Section struct {
-	ID           string  `json:"id"`
-	UserID       string  `json:"user_id"`
-	ProjectID    string  `json:"project_id"` // ID of the project section belongs to
-	AddedAt      string  `json:"added_at"`
-	UpdatedAt    *string `json:"updated_at"`
-	ArchivedAt   *string `json:"archived_at"`
-	Name         string  `json:"name"`
-	SectionOrder int     `json:"section_order"` // Section position among other sections from the same project
-	IsArchived   bool    `json:"is_archived"`
-	IsDeleted    bool    `json:"is_deleted"`
-	IsCollapsed  bool    `json:"is_collapsed"`
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	ProjectID    string     `json:"project_id"` // ID of the project section belongs to
+	AddedAt      *Timestamp `json:"added_at"`
+	UpdatedAt    *Timestamp `json:"updated_at"`
+	ArchivedAt   *Timestamp `json:"archived_at"`
+	Name         string     `json:"name"`
+	SectionOrder int        `json:"section_order"` // Section position among other sections from the same project
+	IsArchived   bool       `json:"is_archived"`
+	IsDeleted    bool       `json:"is_deleted"`
+	IsCollapsed  bool       `json:"is_collapsed"`
 }
 
 // SectionFilters holds the required filter parameters for retrieving sections.
@@ -28,7 +31,17 @@ type SectionFilters struct {
 	PaginationFilters
 }
 
-// SectionOptions holds the parameters for creating or updating a section.
+// SectionUpdate holds the parameters for updating a section. Only the fields
+// that are set are updated.
+type SectionUpdate struct {
+	Name Optional[string] `json:"name"`
+}
+
+func (u SectionUpdate) MarshalJSON() ([]byte, error) {
+	return marshalUpdate(u)
+}
+
+// SectionOptions holds the parameters for creating a section.
 type SectionOptions struct {
 	Name      string `json:"name,omitempty"`
 	ProjectID string `json:"project_id,omitempty"`
@@ -44,10 +57,10 @@ func (c *Client) CreateSection(
 	options *SectionOptions,
 ) (*Section, error) {
 	if name == "" {
-		return nil, fmt.Errorf("name is required")
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidArgument)
 	}
 	if projectID == "" {
-		return nil, fmt.Errorf("project_id is required")
+		return nil, fmt.Errorf("%w: project_id is required", ErrInvalidArgument)
 	}
 
 	if options == nil {
@@ -96,10 +109,31 @@ func (c *Client) GetSections(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
+// AllSections returns an iterator over all active sections for the user or a
+// specific project if filters.ProjectID is provided, requesting further pages
+// as needed.
+func (c *Client) AllSections(
+	ctx context.Context,
+	filters *SectionFilters,
+) iter.Seq2[Section, error] {
+	var f SectionFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]Section, *string, error) {
+			f.Cursor = cursor
+			return c.GetSections(ctx, &f)
+		},
+	)
+}
+
 // GetSection returns the section for the given section ID
 func (c *Client) GetSection(ctx context.Context, id string) (*Section, error) {
 	if id == "" {
-		return nil, fmt.Errorf("section ID is required")
+		return nil, fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(ctx, "GET", fmt.Sprintf("/sections/%s", id), nil, nil)
@@ -117,28 +151,28 @@ func (c *Client) GetSection(ctx context.Context, id string) (*Section, error) {
 	return &section, nil
 }
 
-// UpdateSection updates the section name with the given ID.
+// UpdateSection updates the fields of the section with the given ID that are
+// set in update.
 func (c *Client) UpdateSection(
 	ctx context.Context,
 	id string,
-	name string,
+	update *SectionUpdate,
 ) (*Section, error) {
 	if id == "" {
-		return nil, fmt.Errorf("section ID is required")
+		return nil, fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
 	}
-	if name == "" {
-		return nil, fmt.Errorf("name is required")
+	if update == nil {
+		return nil, fmt.Errorf("%w: update is required", ErrInvalidArgument)
 	}
-
-	options := &SectionOptions{
-		Name: name,
+	if name, _ := update.Name.Get(); update.Name.IsSet() && name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
 		ctx,
 		"POST",
 		fmt.Sprintf("/sections/%s", id),
-		options,
+		update,
 		nil,
 	)
 	if err != nil {
@@ -158,7 +192,7 @@ func (c *Client) UpdateSection(
 // DeleteSection deletes the section with the given ID and all of its tasks.
 func (c *Client) DeleteSection(ctx context.Context, id string) error {
 	if id == "" {
-		return fmt.Errorf("section ID is required")
+		return fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
 	}
 
 	res, err := c.request(
@@ -175,3 +209,206 @@ func (c *Client) DeleteSection(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// ArchivedSectionFilters holds the parameters for retrieving the archived
+// sections of a project. ProjectID is required.
+type ArchivedSectionFilters struct {
+	ProjectID string `json:"project_id"`
+	PaginationFilters
+}
+
+// GetArchivedSections returns the archived sections of a project and a cursor
+// for pagination. The cursor is nil if there are no more pages to return.
+func (c *Client) GetArchivedSections(
+	ctx context.Context,
+	filters *ArchivedSectionFilters,
+) ([]Section, *string, error) {
+	if filters == nil || filters.ProjectID == "" {
+		return nil, nil, fmt.Errorf(
+			"%w: project_id is required",
+			ErrInvalidArgument,
+		)
+	}
+
+	res, err := c.request(ctx, "GET", "/sections/archived", nil, filters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get archived sections: %w", err)
+	}
+	defer res.Body.Close()
+
+	var pagiResp PaginationResponse[Section]
+	err = json.NewDecoder(res.Body).Decode(&pagiResp)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to decode archived sections response: %w",
+			err,
+		)
+	}
+
+	return pagiResp.Results, pagiResp.NextCursor, nil
+}
+
+// AllArchivedSections returns an iterator over the archived sections of a
+// project, requesting further pages as needed.
+func (c *Client) AllArchivedSections(
+	ctx context.Context,
+	filters *ArchivedSectionFilters,
+) iter.Seq2[Section, error] {
+	var f ArchivedSectionFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]Section, *string, error) {
+			f.Cursor = cursor
+			return c.GetArchivedSections(ctx, &f)
+		},
+	)
+}
+
+// ArchiveSection archives the section with the given ID. Its tasks are
+// completed and archived with it.
+func (c *Client) ArchiveSection(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewSectionArchiveCommand(id))
+	if err != nil {
+		return fmt.Errorf("failed to archive section: %w", err)
+	}
+	return nil
+}
+
+// UnarchiveSection restores the archived section with the given ID. Its tasks
+// stay completed.
+func (c *Client) UnarchiveSection(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewSectionUnarchiveCommand(id))
+	if err != nil {
+		return fmt.Errorf("failed to unarchive section: %w", err)
+	}
+	return nil
+}
+
+// ReorderSections sets the order of the sections of a project to the order of
+// sectionIDs in one call. Sections of the project that are not in sectionIDs
+// are placed after them, keeping their current order.
+func (c *Client) ReorderSections(
+	ctx context.Context,
+	projectID string,
+	sectionIDs []string,
+) error {
+	if projectID == "" {
+		return fmt.Errorf("%w: project_id is required", ErrInvalidArgument)
+	}
+
+	var sections []Section
+	for section, err := range c.AllSections(ctx, &SectionFilters{
+		ProjectID: projectID,
+	}) {
+		if err != nil {
+			return fmt.Errorf("failed to reorder sections: %w", err)
+		}
+		sections = append(sections, section)
+	}
+	slices.SortStableFunc(sections, func(a, b Section) int {
+		return cmp.Compare(a.SectionOrder, b.SectionOrder)
+	})
+
+	inProject := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		inProject[section.ID] = true
+	}
+	listed := make(map[string]bool, len(sectionIDs))
+	for _, id := range sectionIDs {
+		if !inProject[id] {
+			return fmt.Errorf(
+				"%w: section %q is not in project %q",
+				ErrInvalidArgument,
+				id,
+				projectID,
+			)
+		}
+		if listed[id] {
+			return fmt.Errorf("%w: duplicate section ID %q", ErrInvalidArgument, id)
+		}
+		listed[id] = true
+	}
+
+	ids := slices.Clone(sectionIDs)
+	for _, section := range sections {
+		if !listed[section.ID] {
+			ids = append(ids, section.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	orders := make([]SectionOrder, len(ids))
+	for i, id := range ids {
+		orders[i] = SectionOrder{ID: id, SectionOrder: i + 1}
+	}
+	_, err := c.Sync.execute(ctx, NewSectionReorderCommand(orders))
+	if err != nil {
+		return fmt.Errorf("failed to reorder sections: %w", err)
+	}
+	return nil
+}
+
+// MoveSection moves the section with the given ID, along with its tasks, to
+// another project.
+func (c *Client) MoveSection(
+	ctx context.Context,
+	id string,
+	projectID string,
+) error {
+	if id == "" {
+		return fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
+	}
+	if projectID == "" {
+		return fmt.Errorf("%w: project_id is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewSectionMoveCommand(id, projectID))
+	if err != nil {
+		return fmt.Errorf("failed to move section: %w", err)
+	}
+	return nil
+}
+
+// CollapseSection collapses the section with the given ID, hiding its tasks
+// in the apps.
+func (c *Client) CollapseSection(ctx context.Context, id string) error {
+	return c.setSectionCollapsed(ctx, id, true)
+}
+
+// ExpandSection expands the collapsed section with the given ID.
+func (c *Client) ExpandSection(ctx context.Context, id string) error {
+	return c.setSectionCollapsed(ctx, id, false)
+}
+
+func (c *Client) setSectionCollapsed(
+	ctx context.Context,
+	id string,
+	collapsed bool,
+) error {
+	if id == "" {
+		return fmt.Errorf("%w: section ID is required", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewSectionUpdateCommand(SectionUpdateArgs{
+		ID:          id,
+		IsCollapsed: &collapsed,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to update section: %w", err)
+	}
+	return nil
+}
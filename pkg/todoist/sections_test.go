@@ -0,0 +1,217 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestAllArchivedSectionsPaginates(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/sections/archived" {
+			t.Errorf("path = %q, want /sections/archived", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("project_id") != "p1" {
+			t.Errorf("project_id = %q, want p1", query.Get("project_id"))
+		}
+		if requests == 1 {
+			fmt.Fprint(w, `{"results":[{"id":"s1","is_archived":true}],`+
+				`"next_cursor":"next"}`)
+			return
+		}
+		if query.Get("cursor") != "next" {
+			t.Errorf("cursor = %q, want next", query.Get("cursor"))
+		}
+		fmt.Fprint(w, `{"results":[{"id":"s2","is_archived":true}],`+
+			`"next_cursor":null}`)
+	})
+
+	var ids []string
+	for section, err := range c.AllArchivedSections(
+		context.Background(),
+		&ArchivedSectionFilters{ProjectID: "p1"},
+	) {
+		if err != nil {
+			t.Fatalf("AllArchivedSections failed: %v", err)
+		}
+		ids = append(ids, section.ID)
+	}
+	if want := []string{"s1", "s2"}; !slices.Equal(ids, want) {
+		t.Errorf("sections = %v, want %v", ids, want)
+	}
+}
+
+func TestGetArchivedSectionsRequiresProject(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	for _, filters := range []*ArchivedSectionFilters{nil, {}} {
+		_, _, err := c.GetArchivedSections(context.Background(), filters)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("error = %v, want ErrInvalidArgument", err)
+		}
+	}
+}
+
+func TestArchiveAndUnarchiveSectionSendCommands(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	if err := c.ArchiveSection(context.Background(), "s1"); err != nil {
+		t.Fatalf("ArchiveSection failed: %v", err)
+	}
+	if err := c.UnarchiveSection(context.Background(), "s2"); err != nil {
+		t.Fatalf("UnarchiveSection failed: %v", err)
+	}
+
+	want := []struct{ commandType, id string }{
+		{CommandSectionArchive, "s1"},
+		{CommandSectionUnarchive, "s2"},
+	}
+	if len(server.requests) != len(want) {
+		t.Fatalf("sent %d requests, want %d", len(server.requests), len(want))
+	}
+	for i, w := range want {
+		command := server.requests[i][0]
+		if command.Type != w.commandType || command.Args["id"] != w.id {
+			t.Errorf(
+				"command %d = %s %v, want %s of %s",
+				i,
+				command.Type,
+				command.Args,
+				w.commandType,
+				w.id,
+			)
+		}
+	}
+}
+
+func TestArchiveSectionRequiresID(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	if err := c.ArchiveSection(context.Background(), ""); !errors.Is(
+		err,
+		ErrInvalidArgument,
+	) {
+		t.Errorf("ArchiveSection error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.UnarchiveSection(context.Background(), ""); !errors.Is(
+		err,
+		ErrInvalidArgument,
+	) {
+		t.Errorf("UnarchiveSection error = %v, want ErrInvalidArgument", err)
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(server.requests))
+	}
+}
+
+// newReorderTestClient returns a client whose project p1 has the sections
+// s1, s2 and s3, sent out of order, and whose writes go to server.
+func newReorderTestClient(t *testing.T, server *syncServer) *Client {
+	server.t = t
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sections" {
+			server.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Query().Get("project_id") != "p1" {
+			t.Errorf("project_id = %q, want p1", r.URL.Query().Get("project_id"))
+		}
+		fmt.Fprint(w, `{"results":[`+
+			`{"id":"s3","project_id":"p1","section_order":3},`+
+			`{"id":"s1","project_id":"p1","section_order":1},`+
+			`{"id":"s2","project_id":"p1","section_order":2}`+
+			`],"next_cursor":null}`)
+	})
+}
+
+// reorderedIDs returns the section IDs of a section_reorder command, in the
+// order of their section_order.
+func reorderedIDs(t *testing.T, command Command) []string {
+	t.Helper()
+	if command.Type != CommandSectionReorder {
+		t.Fatalf("type = %q, want %q", command.Type, CommandSectionReorder)
+	}
+	sections, _ := command.Args["sections"].([]any)
+	ids := make([]string, len(sections))
+	for i, item := range sections {
+		section, _ := item.(map[string]any)
+		if order, _ := section["section_order"].(float64); int(order) != i+1 {
+			t.Errorf("section_order of %v = %v, want %d", section["id"], order, i+1)
+		}
+		ids[i], _ = section["id"].(string)
+	}
+	return ids
+}
+
+func TestReorderSections(t *testing.T) {
+	tests := []struct {
+		name       string
+		sectionIDs []string
+		want       []string
+	}{
+		{"all", []string{"s3", "s1", "s2"}, []string{"s3", "s1", "s2"}},
+		{"unlisted appended", []string{"s3"}, []string{"s3", "s1", "s2"}},
+		{"unlisted keep order", []string{"s2"}, []string{"s2", "s1", "s3"}},
+		{"none", nil, []string{"s1", "s2", "s3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &syncServer{}
+			c := newReorderTestClient(t, server)
+
+			err := c.ReorderSections(context.Background(), "p1", tt.sectionIDs)
+			if err != nil {
+				t.Fatalf("ReorderSections failed: %v", err)
+			}
+			if len(server.requests) != 1 || len(server.requests[0]) != 1 {
+				t.Fatalf("requests = %v, want one command", server.requests)
+			}
+			got := reorderedIDs(t, server.requests[0][0])
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReorderSectionsRejectsInvalidIDs(t *testing.T) {
+	tests := []struct {
+		name       string
+		projectID  string
+		sectionIDs []string
+	}{
+		{"no project", "", []string{"s1"}},
+		{"unknown section", "p1", []string{"s1", "s9"}},
+		{"duplicate section", "p1", []string{"s1", "s2", "s1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &syncServer{}
+			c := newReorderTestClient(t, server)
+
+			err := c.ReorderSections(
+				context.Background(),
+				tt.projectID,
+				tt.sectionIDs,
+			)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("error = %v, want ErrInvalidArgument", err)
+			}
+			if len(server.requests) != 0 {
+				t.Errorf("sent %d writes, want none", len(server.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package todoist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// stateVersion is the version of the State format. States saved with another
+// version are treated as corrupted, which results in a full sync.
+const stateVersion = 1
+
+var (
+	// ErrStateNotFound is returned by a StateStore when no state was saved.
+	ErrStateNotFound = errors.New("state not found")
+
+	// ErrStateCorrupted is returned by a StateStore when the saved state cannot
+	// be read back, e.g. because its checksum does not match.
+	ErrStateCorrupted = errors.New("state corrupted")
+)
+
+// State is the persisted form of a Store: the sync token along with every
+// replicated resource.
+type State struct {
+	Version            int                 `json:"version"`
+	SyncToken          string              `json:"sync_token"`
+	User               *User               `json:"user,omitempty"`
+	UserPlanLimits     *UserPlanLimits     `json:"user_plan_limits,omitempty"`
+	Projects           []Project           `json:"projects"`
+	Items              []Task              `json:"items"`
+	Sections           []Section           `json:"sections"`
+	Labels             []Label             `json:"labels"`
+	Notes              []Comment           `json:"notes"`
+	ProjectNotes       []Comment           `json:"project_notes"`
+	Filters            []Filter            `json:"filters"`
+	Reminders          []Reminder          `json:"reminders"`
+	Collaborators      []Collaborator      `json:"collaborators"`
+	CollaboratorStates []CollaboratorState `json:"collaborator_states"`
+	Workspaces         []Workspace         `json:"workspaces"`
+	WorkspaceUsers     []WorkspaceUser     `json:"workspace_users"`
+	DayOrders          map[string]int      `json:"day_orders"`
+}
+
+// StateStore persists the State of a Store between process restarts.
+type StateStore interface {
+	// Load returns the saved state, ErrStateNotFound if there is none, or
+	// ErrStateCorrupted if it cannot be read back.
+	Load(ctx context.Context) (*State, error)
+
+	// Save replaces the saved state.
+	Save(ctx context.Context, state *State) error
+}
+
+// OpenStore returns a Store restored from the state saved in ss. If there is
+// no saved state, or it is corrupted, the returned Store is empty and its
+// next Refresh performs a full sync. After every Refresh the state of the
+// store is saved back to ss.
+func OpenStore(ctx context.Context, ss StateStore) (*Store, error) {
+	st := NewStore()
+	st.stateStore = ss
+
+	state, err := ss.Load(ctx)
+	switch {
+	case errors.Is(err, ErrStateNotFound), errors.Is(err, ErrStateCorrupted):
+		return st, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	st.Restore(state)
+	return st, nil
+}
+
+// State returns a snapshot of the store that can be saved with a StateStore.
+func (st *Store) State() *State {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	state := &State{
+		Version:      stateVersion,
+		SyncToken:    st.syncToken,
+		Projects:     values(st.projects, nil, compareProjects),
+		Items:        values(st.items, nil, compareTasks),
+		Sections:     values(st.sections, nil, compareSections),
+		Labels:       values(st.labels, nil, compareLabels),
+		Notes:        values(st.notes, nil, compareComments),
+		ProjectNotes: values(st.projectNotes, nil, compareComments),
+		Filters:      values(st.filters, nil, compareFilters),
+		Reminders: values(
+			st.reminders,
+			nil,
+			compareByID(reminderKind.key),
+		),
+		Collaborators: values(
+			st.collaborators,
+			nil,
+			compareByID(collaboratorKind.key),
+		),
+		CollaboratorStates: values(
+			st.collaboratorStates,
+			nil,
+			compareByID(collaboratorStateKind.key),
+		),
+		Workspaces: values(
+			st.workspaces,
+			nil,
+			compareByID(workspaceKind.key),
+		),
+		WorkspaceUsers: values(
+			st.workspaceUsers,
+			nil,
+			compareByID(workspaceUserKind.key),
+		),
+		DayOrders: make(map[string]int, len(st.dayOrders)),
+	}
+	if st.user != nil {
+		user := *st.user
+		state.User = &user
+	}
+	if st.userPlanLimits != nil {
+		limits := *st.userPlanLimits
+		state.UserPlanLimits = &limits
+	}
+	for id, order := range st.dayOrders {
+		state.DayOrders[id] = order
+	}
+	return state
+}
+
+// Restore replaces the content of the store with the given state.
+func (st *Store) Restore(state *State) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.syncToken = state.SyncToken
+	if st.syncToken == "" {
+		st.syncToken = "*"
+	}
+	st.user = state.User
+	st.userPlanLimits = state.UserPlanLimits
+	st.projects = merge(nil, state.Projects, true, projectKind, nil)
+	st.items = merge(nil, state.Items, true, taskKind, nil)
+	st.sections = merge(nil, state.Sections, true, sectionKind, nil)
+	st.labels = merge(nil, state.Labels, true, labelKind, nil)
+	st.notes = merge(nil, state.Notes, true, noteKind, nil)
+	st.projectNotes = merge(nil, state.ProjectNotes, true, projectNoteKind, nil)
+	st.filters = merge(nil, state.Filters, true, filterKind, nil)
+	st.reminders = merge(nil, state.Reminders, true, reminderKind, nil)
+	st.collaborators = merge(
+		nil,
+		state.Collaborators,
+		true,
+		collaboratorKind,
+		nil,
+	)
+	st.collaboratorStates = merge(
+		nil,
+		state.CollaboratorStates,
+		true,
+		collaboratorStateKind,
+		nil,
+	)
+	st.workspaces = merge(nil, state.Workspaces, true, workspaceKind, nil)
+	st.workspaceUsers = merge(
+		nil,
+		state.WorkspaceUsers,
+		true,
+		workspaceUserKind,
+		nil,
+	)
+	st.dayOrders = make(map[string]int, len(state.DayOrders))
+	for id, order := range state.DayOrders {
+		st.dayOrders[id] = order
+	}
+}
+
+// Save saves the state of the store to the StateStore it was opened with. It
+// does nothing for stores created with NewStore.
+func (st *Store) Save(ctx context.Context) error {
+	if st.stateStore == nil {
+		return nil
+	}
+	if err := st.stateStore.Save(ctx, st.State()); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+// stateEnvelope wraps an encoded State with a checksum used to detect
+// corruption.
+type stateEnvelope struct {
+	Checksum string          `json:"checksum"` // Hex encoded SHA-256 of State
+	State    json.RawMessage `json:"state"`
+}
+
+// encodeState encodes state along with its checksum.
+func encodeState(state *State) ([]byte, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return json.Marshal(stateEnvelope{
+		Checksum: hex.EncodeToString(sum[:]),
+		State:    data,
+	})
+}
+
+// decodeState decodes data produced by encodeState. Any failure is reported
+// as ErrStateCorrupted.
+func decodeState(data []byte) (*State, error) {
+	var envelope stateEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrStateCorrupted, err)
+	}
+
+	sum := sha256.Sum256(envelope.State)
+	if hex.EncodeToString(sum[:]) != envelope.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrStateCorrupted)
+	}
+
+	var state State
+	if err := json.Unmarshal(envelope.State, &state); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrStateCorrupted, err)
+	}
+	if state.Version != stateVersion {
+		return nil, fmt.Errorf(
+			"%w: unsupported version %d",
+			ErrStateCorrupted,
+			state.Version,
+		)
+	}
+	return &state, nil
+}
+
+// FileStateStore saves the state as a JSON file. The file is written to a
+// temporary file first and then renamed, so a crash while saving never leaves
+// a partially written state behind.
+type FileStateStore struct {
+	Path string
+}
+
+// NewFileStateStore returns a FileStateStore that saves the state to path.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{Path: path}
+}
+
+func (f *FileStateStore) Load(ctx context.Context) (*State, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeState(data)
+}
+
+func (f *FileStateStore) Save(ctx context.Context, state *State) error {
+	data, err := encodeState(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(f.Path, data)
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory as
+// path, flushes it to disk and renames it to path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// KeyValueStore is a minimal interface over an embedded key-value database,
+// used by KVStateStore. Get returns ErrStateNotFound when the key does not
+// exist. The boltkv package implements it with a bbolt database.
+type KeyValueStore interface {
+	Get(key string) ([]byte, error)
+	Put(key string, value []byte) error
+}
+
+// KVStateStore saves the state under a single key of a KeyValueStore.
+type KVStateStore struct {
+	KV  KeyValueStore
+	Key string
+}
+
+// NewKVStateStore returns a KVStateStore that saves the state under key.
+func NewKVStateStore(kv KeyValueStore, key string) *KVStateStore {
+	return &KVStateStore{KV: kv, Key: key}
+}
+
+func (s *KVStateStore) Load(ctx context.Context) (*State, error) {
+	data, err := s.KV.Get(s.Key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeState(data)
+}
+
+func (s *KVStateStore) Save(ctx context.Context, state *State) error {
+	data, err := encodeState(state)
+	if err != nil {
+		return err
+	}
+	return s.KV.Put(s.Key, data)
+}
+
+// DirKV is a KeyValueStore that stores every key as a file in a directory.
+// Values are written atomically.
+type DirKV struct {
+	Dir string
+}
+
+// NewDirKV returns a DirKV using dir, creating it if needed.
+func NewDirKV(dir string) (*DirKV, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DirKV{Dir: dir}, nil
+}
+
+func (d *DirKV) Get(key string) ([]byte, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrStateNotFound
+	}
+	return data, err
+}
+
+func (d *DirKV) Put(key string, value []byte) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, value)
+}
+
+// path returns the file used for key. Keys are escaped so that they cannot
+// contain a path separator, and the keys ".", ".." and "" are rejected as
+// they would refer to the directory itself or to its parent.
+func (d *DirKV) path(key string) (string, error) {
+	switch key {
+	case "", ".", "..":
+		return "", fmt.Errorf("%w: invalid key %q", ErrInvalidArgument, key)
+	}
+	return filepath.Join(d.Dir, url.PathEscape(key)), nil
+}
+
+// MemoryKV is a KeyValueStore that keeps values in memory. It is mainly
+// useful in tests.
+type MemoryKV struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+// NewMemoryKV returns an empty MemoryKV.
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{values: map[string][]byte{}}
+}
+
+func (m *MemoryKV) Get(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (m *MemoryKV) Put(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = append([]byte(nil), value...)
+	return nil
+}
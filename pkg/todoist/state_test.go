@@ -0,0 +1,88 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirKVRejectsKeysOutsideDir(t *testing.T) {
+	parent := t.TempDir()
+	kv, err := NewDirKV(filepath.Join(parent, "kv"))
+	if err != nil {
+		t.Fatalf("NewDirKV failed: %v", err)
+	}
+
+	for _, key := range []string{"", ".", ".."} {
+		if err := kv.Put(key, []byte("x")); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidArgument", key, err)
+		}
+		if _, err := kv.Get(key); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Get(%q) error = %v, want ErrInvalidArgument", key, err)
+		}
+	}
+
+	if err := kv.Put("../escape", []byte("x")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%d entries next to the directory, want only the directory", len(entries))
+	}
+	value, err := kv.Get("../escape")
+	if err != nil || string(value) != "x" {
+		t.Errorf("Get = %q, %v, want %q", value, err, "x")
+	}
+}
+
+func TestKVStateStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	kv, err := NewDirKV(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirKV failed: %v", err)
+	}
+	ss := NewKVStateStore(kv, "replica")
+
+	st, err := OpenStore(ctx, ss)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	st.Apply(&SyncReadResponse{
+		SyncToken: "token",
+		FullSync:  true,
+		Projects:  []Project{{ID: "1", Name: "Inbox"}},
+	})
+	if err := st.Save(ctx); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored, err := OpenStore(ctx, ss)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if restored.SyncToken() != "token" {
+		t.Errorf("SyncToken = %q, want %q", restored.SyncToken(), "token")
+	}
+	if _, ok := restored.Project("1"); !ok {
+		t.Error("project 1 was not restored")
+	}
+}
+
+func TestOpenStoreFallsBackOnCorruption(t *testing.T) {
+	ctx := context.Background()
+	kv := NewMemoryKV()
+	kv.Put("replica", []byte(`{"checksum":"bad","state":{}}`))
+
+	st, err := OpenStore(ctx, NewKVStateStore(kv, "replica"))
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if st.SyncToken() != "*" {
+		t.Errorf("SyncToken = %q, want a full sync", st.SyncToken())
+	}
+}
@@ -0,0 +1,659 @@
+package todoist
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store is an in-memory replica of a user's Todoist data that is kept up to
+// date by applying Sync API responses. Full sync responses replace the
+// resources they contain, while incremental responses are merged into the
+// existing resources, and resources flagged with is_deleted are removed.
+//
+// A Store is safe for concurrent use. The lookup methods return copies of
+// the stored resources.
+//
+// Example:
+//
+//	store := todoist.NewStore()
+//	_, err := store.Refresh(ctx, client.Sync, []string{"all"})
+//	tasks := store.TasksByProject(projectID)
+type Store struct {
+	mu sync.RWMutex
+
+	syncToken          string
+	user               *User
+	userPlanLimits     *UserPlanLimits
+	projects           map[string]Project
+	items              map[string]Task
+	sections           map[string]Section
+	labels             map[string]Label
+	notes              map[string]Comment
+	projectNotes       map[string]Comment
+	filters            map[string]Filter
+	reminders          map[string]Reminder
+	collaborators      map[string]Collaborator
+	collaboratorStates map[string]CollaboratorState
+	workspaces         map[string]Workspace
+	workspaceUsers     map[string]WorkspaceUser
+	dayOrders          map[string]int
+
+	stateStore StateStore // Set by OpenStore
+}
+
+// NewStore returns an empty Store. Its sync token is "*", so the first
+// Refresh performs a full sync.
+func NewStore() *Store {
+	return &Store{
+		syncToken:          "*",
+		projects:           map[string]Project{},
+		items:              map[string]Task{},
+		sections:           map[string]Section{},
+		labels:             map[string]Label{},
+		notes:              map[string]Comment{},
+		projectNotes:       map[string]Comment{},
+		filters:            map[string]Filter{},
+		reminders:          map[string]Reminder{},
+		collaborators:      map[string]Collaborator{},
+		collaboratorStates: map[string]CollaboratorState{},
+		workspaces:         map[string]Workspace{},
+		workspaceUsers:     map[string]WorkspaceUser{},
+		dayOrders:          map[string]int{},
+	}
+}
+
+// Refresh reads the given resource types with s, starting from the sync
+// token of the store, and applies the response. Pass nil resourceTypes to
+// read the same resource types as the previous call on s. If the store was
+// opened with OpenStore, its state is saved after the response is applied.
+func (st *Store) Refresh(
+	ctx context.Context,
+	s *Sync,
+	resourceTypes []string,
+) (*SyncReadResponse, error) {
+	if resourceTypes == nil {
+		resourceTypes = s.ResourceTypes
+	}
+	if len(resourceTypes) == 0 {
+		resourceTypes = []string{"all"}
+	}
+
+	s.SyncToken = st.SyncToken()
+	resp, err := s.ReadResources(ctx, resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+	st.Apply(resp)
+	if err := st.Save(ctx); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Apply merges a Sync API response into the store and updates its sync
+// token.
+func (st *Store) Apply(resp *SyncReadResponse) {
+	st.apply(resp, nil)
+}
+
+// ApplyWithEvents merges a Sync API response into the store like Apply and
+// returns the changes it made to the store as events.
+func (st *Store) ApplyWithEvents(resp *SyncReadResponse) []Event {
+	events := []Event{}
+	st.apply(resp, &events)
+	return events
+}
+
+// apply merges resp into the store. If events is not nil, the changes made
+// to the store are appended to it.
+func (st *Store) apply(resp *SyncReadResponse, events *[]Event) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	full := resp.FullSync
+	if resp.SyncToken != "" {
+		st.syncToken = resp.SyncToken
+	}
+	if resp.User.ID != "" {
+		user := resp.User
+		st.user = &user
+	}
+	if resp.UserPlanLimits.Current.PlanName != "" {
+		limits := resp.UserPlanLimits
+		st.userPlanLimits = &limits
+	}
+
+	st.projects = merge(st.projects, resp.Projects, full, projectKind, events)
+	st.items = merge(st.items, resp.Items, full, taskKind, events)
+	st.sections = merge(st.sections, resp.Sections, full, sectionKind, events)
+	st.labels = merge(st.labels, resp.Labels, full, labelKind, events)
+	st.notes = merge(st.notes, resp.Notes, full, noteKind, events)
+	st.projectNotes = merge(
+		st.projectNotes,
+		resp.ProjectNotes,
+		full,
+		projectNoteKind,
+		events,
+	)
+	st.filters = merge(st.filters, resp.Filters, full, filterKind, events)
+	st.reminders = merge(st.reminders, resp.Reminders, full, reminderKind, events)
+	st.collaborators = merge(
+		st.collaborators,
+		resp.Collaborators,
+		full,
+		collaboratorKind,
+		events,
+	)
+	st.collaboratorStates = merge(
+		st.collaboratorStates,
+		resp.CollaboratorStates,
+		full,
+		collaboratorStateKind,
+		events,
+	)
+	if resp.Workspaces != nil {
+		st.workspaces = merge(
+			st.workspaces,
+			*resp.Workspaces,
+			full,
+			workspaceKind,
+			events,
+		)
+	}
+	// Workspace users are only sent in incremental syncs, so they are never
+	// reset by a full sync.
+	st.workspaceUsers = merge(
+		st.workspaceUsers,
+		resp.WorkspaceUsers,
+		false,
+		workspaceUserKind,
+		events,
+	)
+
+	if full && resp.DayOrders != nil {
+		st.dayOrders = map[string]int{}
+	}
+	for id, order := range resp.DayOrders {
+		st.dayOrders[id] = order
+	}
+}
+
+// resourceKind describes how the records of a resource type are identified.
+type resourceKind[T any] struct {
+	resource ResourceType
+	key      func(T) string
+	deleted  func(T) bool
+}
+
+var (
+	projectKind = resourceKind[Project]{
+		resource: ResourceProject,
+		key:      func(p Project) string { return p.ID },
+		deleted:  func(p Project) bool { return p.IsDeleted },
+	}
+	taskKind = resourceKind[Task]{
+		resource: ResourceItem,
+		key:      func(t Task) string { return t.ID },
+		deleted:  func(t Task) bool { return t.IsDeleted },
+	}
+	sectionKind = resourceKind[Section]{
+		resource: ResourceSection,
+		key:      func(s Section) string { return s.ID },
+		deleted:  func(s Section) bool { return s.IsDeleted },
+	}
+	labelKind = resourceKind[Label]{
+		resource: ResourceLabel,
+		key:      func(l Label) string { return l.ID },
+		deleted:  func(l Label) bool { return l.IsDeleted },
+	}
+	noteKind = resourceKind[Comment]{
+		resource: ResourceNote,
+		key:      func(c Comment) string { return c.ID },
+		deleted:  func(c Comment) bool { return c.IsDeleted },
+	}
+	projectNoteKind = resourceKind[Comment]{
+		resource: ResourceProjectNote,
+		key:      func(c Comment) string { return c.ID },
+		deleted:  func(c Comment) bool { return c.IsDeleted },
+	}
+	filterKind = resourceKind[Filter]{
+		resource: ResourceFilter,
+		key:      func(f Filter) string { return f.ID },
+		deleted:  func(f Filter) bool { return f.IsDeleted },
+	}
+	reminderKind = resourceKind[Reminder]{
+		resource: ResourceReminder,
+		key:      func(r Reminder) string { return r.ID },
+		deleted:  func(r Reminder) bool { return r.IsDeleted },
+	}
+	collaboratorKind = resourceKind[Collaborator]{
+		resource: ResourceCollaborator,
+		key:      func(c Collaborator) string { return c.ID },
+		deleted:  func(Collaborator) bool { return false },
+	}
+	collaboratorStateKind = resourceKind[CollaboratorState]{
+		resource: ResourceCollaboratorState,
+		key: func(c CollaboratorState) string {
+			return c.ProjectID + ":" + c.UserID
+		},
+		deleted: func(c CollaboratorState) bool { return c.IsDeleted },
+	}
+	workspaceKind = resourceKind[Workspace]{
+		resource: ResourceWorkspace,
+		key:      func(w Workspace) string { return w.ID },
+		deleted:  func(w Workspace) bool { return w.IsDeleted },
+	}
+	workspaceUserKind = resourceKind[WorkspaceUser]{
+		resource: ResourceWorkspaceUser,
+		key: func(u WorkspaceUser) string {
+			return u.WorkspaceID + ":" + u.UserID
+		},
+		deleted: func(u WorkspaceUser) bool { return u.IsDeleted },
+	}
+)
+
+// merge applies records to m and returns it. When full is set and records
+// were included in the response, m is replaced by the records instead. If
+// events is not nil, an event is appended for every record that is created,
+// updated or deleted.
+func merge[T any](
+	m map[string]T,
+	records []T,
+	full bool,
+	kind resourceKind[T],
+	events *[]Event,
+) map[string]T {
+	previous := m
+	replaced := m == nil || (full && records != nil)
+	if replaced {
+		m = make(map[string]T, len(records))
+	}
+
+	seen := map[string]bool{}
+	for _, record := range records {
+		key := kind.key(record)
+		seen[key] = true
+		old, existed := previous[key]
+
+		if kind.deleted(record) {
+			delete(m, key)
+			if existed {
+				appendEvent(events, kind, EventDeleted, key, &old, nil)
+			}
+			continue
+		}
+
+		m[key] = record
+		if existed {
+			appendEvent(events, kind, EventUpdated, key, &old, &record)
+		} else {
+			appendEvent(events, kind, EventCreated, key, nil, &record)
+		}
+	}
+
+	// Records missing from a full sync no longer exist.
+	if replaced && events != nil {
+		for _, key := range slices.Sorted(maps.Keys(previous)) {
+			if !seen[key] {
+				old := previous[key]
+				appendEvent(events, kind, EventDeleted, key, &old, nil)
+			}
+		}
+	}
+	return m
+}
+
+// values returns the records of m that match keep, sorted with compare.
+func values[T any](
+	m map[string]T,
+	keep func(T) bool,
+	compare func(a, b T) int,
+) []T {
+	var out []T
+	for _, record := range m {
+		if keep == nil || keep(record) {
+			out = append(out, record)
+		}
+	}
+	slices.SortFunc(out, compare)
+	return out
+}
+
+func compareProjects(a, b Project) int {
+	return cmp.Or(cmp.Compare(a.ChildOrder, b.ChildOrder), cmp.Compare(a.ID, b.ID))
+}
+
+func compareTasks(a, b Task) int {
+	return cmp.Or(cmp.Compare(a.ChildOrder, b.ChildOrder), cmp.Compare(a.ID, b.ID))
+}
+
+func compareSections(a, b Section) int {
+	return cmp.Or(
+		cmp.Compare(a.SectionOrder, b.SectionOrder),
+		cmp.Compare(a.ID, b.ID),
+	)
+}
+
+func compareLabels(a, b Label) int {
+	var orderA, orderB int
+	if a.Order != nil {
+		orderA = *a.Order
+	}
+	if b.Order != nil {
+		orderB = *b.Order
+	}
+	return cmp.Or(cmp.Compare(orderA, orderB), cmp.Compare(a.ID, b.ID))
+}
+
+func compareComments(a, b Comment) int {
+	var postedA, postedB time.Time
+	if a.PostedAt != nil {
+		postedA = a.PostedAt.Time
+	}
+	if b.PostedAt != nil {
+		postedB = b.PostedAt.Time
+	}
+	return cmp.Or(postedA.Compare(postedB), cmp.Compare(a.ID, b.ID))
+}
+
+func compareFilters(a, b Filter) int {
+	return cmp.Or(cmp.Compare(a.ItemOrder, b.ItemOrder), cmp.Compare(a.ID, b.ID))
+}
+
+func compareByID[T any](key func(T) string) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// SyncToken returns the token to use for the next incremental sync.
+func (st *Store) SyncToken() string {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.syncToken
+}
+
+// User returns the synced user, or nil if the user has not been synced.
+func (st *Store) User() *User {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	if st.user == nil {
+		return nil
+	}
+	user := *st.user
+	return &user
+}
+
+// UserPlanLimits returns the synced plan limits of the user, or nil if they
+// have not been synced.
+func (st *Store) UserPlanLimits() *UserPlanLimits {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	if st.userPlanLimits == nil {
+		return nil
+	}
+	limits := *st.userPlanLimits
+	return &limits
+}
+
+// Project returns the project with the given ID.
+func (st *Store) Project(id string) (Project, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.projects[id]
+	return record, ok
+}
+
+// Projects returns all projects ordered by child_order.
+func (st *Store) Projects() []Project {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.projects, nil, compareProjects)
+}
+
+// Subprojects returns the direct children of the given project ordered by
+// child_order. An empty parentID returns the root projects.
+func (st *Store) Subprojects(parentID string) []Project {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.projects, func(p Project) bool {
+		return stringValue(p.ParentID) == parentID
+	}, compareProjects)
+}
+
+// Task returns the task with the given ID.
+func (st *Store) Task(id string) (Task, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.items[id]
+	return record, ok
+}
+
+// Tasks returns all tasks ordered by child_order.
+func (st *Store) Tasks() []Task {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.items, nil, compareTasks)
+}
+
+// TasksByProject returns the tasks of a project ordered by child_order.
+func (st *Store) TasksByProject(projectID string) []Task {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.items, func(t Task) bool {
+		return t.ProjectID == projectID
+	}, compareTasks)
+}
+
+// TasksBySection returns the tasks of a section ordered by child_order.
+func (st *Store) TasksBySection(sectionID string) []Task {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.items, func(t Task) bool {
+		return stringValue(t.SectionID) == sectionID
+	}, compareTasks)
+}
+
+// Subtasks returns the direct subtasks of a task ordered by child_order.
+func (st *Store) Subtasks(parentID string) []Task {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.items, func(t Task) bool {
+		return stringValue(t.ParentID) == parentID
+	}, compareTasks)
+}
+
+// Section returns the section with the given ID.
+func (st *Store) Section(id string) (Section, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.sections[id]
+	return record, ok
+}
+
+// Sections returns all sections ordered by section_order.
+func (st *Store) Sections() []Section {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.sections, nil, compareSections)
+}
+
+// SectionsByProject returns the sections of a project ordered by
+// section_order.
+func (st *Store) SectionsByProject(projectID string) []Section {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.sections, func(s Section) bool {
+		return s.ProjectID == projectID
+	}, compareSections)
+}
+
+// Label returns the personal label with the given ID.
+func (st *Store) Label(id string) (Label, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.labels[id]
+	return record, ok
+}
+
+// Labels returns all personal labels ordered by their order.
+func (st *Store) Labels() []Label {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.labels, nil, compareLabels)
+}
+
+// Note returns the task comment with the given ID.
+func (st *Store) Note(id string) (Comment, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.notes[id]
+	return record, ok
+}
+
+// NotesByTask returns the comments of a task ordered by posting time.
+func (st *Store) NotesByTask(taskID string) []Comment {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.notes, func(c Comment) bool {
+		return c.ItemID == taskID
+	}, compareComments)
+}
+
+// ProjectNote returns the project comment with the given ID.
+func (st *Store) ProjectNote(id string) (Comment, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.projectNotes[id]
+	return record, ok
+}
+
+// ProjectNotesByProject returns the comments of a project ordered by posting
+// time.
+func (st *Store) ProjectNotesByProject(projectID string) []Comment {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.projectNotes, func(c Comment) bool {
+		return c.ProjectID == projectID
+	}, compareComments)
+}
+
+// Filter returns the filter with the given ID.
+func (st *Store) Filter(id string) (Filter, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.filters[id]
+	return record, ok
+}
+
+// Filters returns all filters ordered by item_order.
+func (st *Store) Filters() []Filter {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.filters, nil, compareFilters)
+}
+
+// Reminder returns the reminder with the given ID.
+func (st *Store) Reminder(id string) (Reminder, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.reminders[id]
+	return record, ok
+}
+
+// Reminders returns all reminders ordered by ID.
+func (st *Store) Reminders() []Reminder {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.reminders, nil, compareByID(reminderKind.key))
+}
+
+// RemindersByTask returns the reminders of a task ordered by ID.
+func (st *Store) RemindersByTask(taskID string) []Reminder {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.reminders, func(r Reminder) bool {
+		return r.ItemID == taskID
+	}, compareByID(reminderKind.key))
+}
+
+// Collaborator returns the collaborator with the given user ID.
+func (st *Store) Collaborator(id string) (Collaborator, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.collaborators[id]
+	return record, ok
+}
+
+// Collaborators returns all collaborators ordered by ID.
+func (st *Store) Collaborators() []Collaborator {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.collaborators, nil, compareByID(collaboratorKind.key))
+}
+
+// ProjectCollaborators returns the collaborators of a shared project ordered
+// by ID, including users that have been invited but not joined yet.
+func (st *Store) ProjectCollaborators(projectID string) []Collaborator {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.collaborators, func(c Collaborator) bool {
+		_, ok := st.collaboratorStates[projectID+":"+c.ID]
+		return ok
+	}, compareByID(collaboratorKind.key))
+}
+
+// CollaboratorStates returns the collaborator states of a project ordered by
+// user ID.
+func (st *Store) CollaboratorStates(projectID string) []CollaboratorState {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.collaboratorStates, func(c CollaboratorState) bool {
+		return c.ProjectID == projectID
+	}, compareByID(collaboratorStateKind.key))
+}
+
+// Workspace returns the workspace with the given ID.
+func (st *Store) Workspace(id string) (Workspace, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.workspaces[id]
+	return record, ok
+}
+
+// Workspaces returns all workspaces ordered by ID.
+func (st *Store) Workspaces() []Workspace {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.workspaces, nil, compareByID(workspaceKind.key))
+}
+
+// WorkspaceUsers returns the members of a workspace seen in incremental syncs,
+// ordered by user ID.
+func (st *Store) WorkspaceUsers(workspaceID string) []WorkspaceUser {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return values(st.workspaceUsers, func(u WorkspaceUser) bool {
+		return u.WorkspaceID == workspaceID
+	}, compareByID(workspaceUserKind.key))
+}
+
+// DayOrder returns the order of a task in the Today and Next 7 days views.
+func (st *Store) DayOrder(taskID string) (int, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	record, ok := st.dayOrders[taskID]
+	return record, ok
+}
+
+// stringValue returns the value of s, or an empty string if s is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
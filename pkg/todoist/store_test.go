@@ -0,0 +1,128 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+)
+
+// taskContents returns the content of the tasks in the store, in order.
+func taskContents(st *Store) []string {
+	var contents []string
+	for _, task := range st.Tasks() {
+		contents = append(contents, task.Content)
+	}
+	return contents
+}
+
+func TestStoreFullSyncReplacesState(t *testing.T) {
+	st := NewStore()
+	st.Apply(&SyncReadResponse{
+		SyncToken: "1",
+		FullSync:  true,
+		Items: []Task{
+			{ID: "1", Content: "Milk", ChildOrder: 1},
+			{ID: "2", Content: "Eggs", ChildOrder: 2},
+		},
+		Projects:  []Project{{ID: "p", Name: "Inbox"}},
+		DayOrders: map[string]int{"1": 1},
+	})
+	st.Apply(&SyncReadResponse{
+		SyncToken: "2",
+		FullSync:  true,
+		Items:     []Task{{ID: "3", Content: "Bread"}},
+		DayOrders: map[string]int{},
+	})
+
+	if st.SyncToken() != "2" {
+		t.Errorf("SyncToken = %q, want %q", st.SyncToken(), "2")
+	}
+	if got := taskContents(st); !slices.Equal(got, []string{"Bread"}) {
+		t.Errorf("tasks = %v, want [Bread]", got)
+	}
+	if _, ok := st.Project("p"); !ok {
+		t.Error("projects were reset by a full sync that did not include them")
+	}
+	if _, ok := st.DayOrder("1"); ok {
+		t.Error("day orders were not replaced")
+	}
+}
+
+func TestStoreIncrementalSyncMerges(t *testing.T) {
+	st := NewStore()
+	st.Apply(&SyncReadResponse{
+		SyncToken: "1",
+		FullSync:  true,
+		Items: []Task{
+			{ID: "1", Content: "Milk", ChildOrder: 1},
+			{ID: "2", Content: "Eggs", ChildOrder: 2},
+		},
+		Labels: []Label{{ID: "l", Name: "errand"}},
+	})
+	st.Apply(&SyncReadResponse{
+		SyncToken: "2",
+		Items: []Task{
+			{ID: "1", Content: "Oat milk", ChildOrder: 1},
+			{ID: "3", Content: "Bread", ChildOrder: 3},
+		},
+	})
+
+	want := []string{"Oat milk", "Eggs", "Bread"}
+	if got := taskContents(st); !slices.Equal(got, want) {
+		t.Errorf("tasks = %v, want %v", got, want)
+	}
+	if _, ok := st.Label("l"); !ok {
+		t.Error("label missing after an incremental sync")
+	}
+}
+
+func TestStoreRemovesDeletedRecords(t *testing.T) {
+	st := NewStore()
+	st.Apply(&SyncReadResponse{
+		SyncToken: "1",
+		FullSync:  true,
+		Items: []Task{
+			{ID: "1", Content: "Milk"},
+			{ID: "2", Content: "Eggs"},
+		},
+		Sections: []Section{{ID: "s", Name: "Dairy"}},
+	})
+	events := st.ApplyWithEvents(&SyncReadResponse{
+		SyncToken: "2",
+		Items: []Task{
+			{ID: "1", IsDeleted: true},
+			{ID: "4", IsDeleted: true}, // Never stored
+		},
+		Sections: []Section{{ID: "s", IsDeleted: true}},
+	})
+
+	if _, ok := st.Task("1"); ok {
+		t.Error("deleted task is still in the store")
+	}
+	if _, ok := st.Section("s"); ok {
+		t.Error("deleted section is still in the store")
+	}
+	if got := taskContents(st); !slices.Equal(got, []string{"Eggs"}) {
+		t.Errorf("tasks = %v, want [Eggs]", got)
+	}
+	if len(events) != 2 {
+		t.Errorf("%d events, want 2 deletions", len(events))
+	}
+	for _, event := range events {
+		if event.Type != EventDeleted {
+			t.Errorf("event type = %s, want %s", event.Type, EventDeleted)
+		}
+	}
+}
+
+func TestStoreKeepsWorkspaceUsersOnFullSync(t *testing.T) {
+	st := NewStore()
+	st.Apply(&SyncReadResponse{
+		SyncToken:      "1",
+		WorkspaceUsers: []WorkspaceUser{{WorkspaceID: "w", UserID: "u"}},
+	})
+	st.Apply(&SyncReadResponse{SyncToken: "2", FullSync: true})
+
+	if len(st.WorkspaceUsers("w")) != 1 {
+		t.Error("workspace users were reset by a full sync")
+	}
+}
@@ -3,13 +3,19 @@ package todoist
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 )
 
+// DefaultMaxCommandsPerRequest is the maximum number of commands the Sync API
+// accepts in a single request.
+const DefaultMaxCommandsPerRequest = 100
+
 type Sync struct {
 	// A special string, used to allow the client to perform incremental sync. Pass * to retrieve
 	// all active resource data. More details about this below.
@@ -25,6 +31,12 @@ type Sync struct {
 
 	Commands []Command `json:"commands"`
 	APIKey   string    `json:"-"`
+
+	// MaxCommandsPerRequest is the number of commands WriteCommands sends per
+	// request. DefaultMaxCommandsPerRequest is used when it is zero.
+	MaxCommandsPerRequest int `json:"-"`
+
+	client *Client // Client used to send requests, set by NewClient
 }
 
 // type String	The type of the command.
@@ -33,6 +45,9 @@ type Sync struct {
 // temp_id String	Temporary resource ID, Optional. Only specified for commands
 // that create a new
 // resource (e.g. item_add command). More details about this belowV
+//
+// Commands are usually built with the New*Command functions, such as
+// NewItemAddCommand, which fill in the UUID and TempID.
 type Command struct {
 	Type   string         `json:"type"`
 	Args   map[string]any `json:"args"`
@@ -68,11 +83,103 @@ type SyncReadResponse struct {
 }
 
 type SyncWriteResponse struct {
-	SyncToken     string            `json:"sync_token"`
-	SyncStatus    map[string]string `json:"sync_status"`
-	TempIDMapping map[string]string `json:"temp_id_mapping,omitempty"`
+	SyncToken     string                   `json:"sync_token"`
+	SyncStatus    map[string]CommandStatus `json:"sync_status"`
+	TempIDMapping map[string]string        `json:"temp_id_mapping,omitempty"`
+}
+
+// CommandStatus is the status of a single command in a SyncWriteResponse. The
+// API reports either the string "ok" or an error object for every command
+// UUID.
+type CommandStatus struct {
+	Error *CommandError // nil if the command was applied
+}
+
+// OK reports whether the command was applied.
+func (s CommandStatus) OK() bool {
+	return s.Error == nil
+}
+
+func (s *CommandStatus) UnmarshalJSON(data []byte) error {
+	var status string
+	if err := json.Unmarshal(data, &status); err == nil {
+		if status != "ok" {
+			s.Error = &CommandError{Message: status}
+		}
+		return nil
+	}
+
+	var cmdErr CommandError
+	if err := json.Unmarshal(data, &cmdErr); err != nil {
+		return err
+	}
+	s.Error = &cmdErr
+	return nil
+}
+
+func (s CommandStatus) MarshalJSON() ([]byte, error) {
+	if s.Error == nil {
+		return json.Marshal("ok")
+	}
+	return json.Marshal(s.Error)
+}
+
+// CommandError describes why the API rejected a command. It matches the
+// sentinel errors in the same way as *APIError, based on HTTPCode.
+type CommandError struct {
+	Message    string         `json:"error"`
+	ErrorCode  int            `json:"error_code"`
+	ErrorTag   string         `json:"error_tag,omitempty"`
+	HTTPCode   int            `json:"http_code,omitempty"`
+	ErrorExtra map[string]any `json:"error_extra,omitempty"`
+}
+
+func (e *CommandError) Error() string {
+	if e.ErrorTag != "" {
+		return fmt.Sprintf(
+			"command error: %s (%s, code %d)",
+			e.Message,
+			e.ErrorTag,
+			e.ErrorCode,
+		)
+	}
+	return "command error: " + e.Message
+}
+
+// Is reports whether the error matches one of the sentinel errors based on
+// its HTTP code.
+func (e *CommandError) Is(target error) bool {
+	return target != nil && target == sentinelForStatus(e.HTTPCode)
 }
 
+// SyncWriteResult is the outcome of WriteCommands.
+type SyncWriteResult struct {
+	SyncToken     string            // The new SyncToken of the Sync
+	TempIDMapping map[string]string // Temporary IDs mapped to real IDs
+
+	// Applied contains the UUIDs of the commands that were applied, in the
+	// order they were queued.
+	Applied []string
+
+	// Failed contains the commands that were not applied, keyed by UUID. These
+	// commands are kept in Sync.Commands.
+	Failed map[string]*CommandError
+}
+
+// Err returns an error describing every failed command, or nil if all
+// commands were applied.
+func (r *SyncWriteResult) Err() error {
+	var errs []error
+	for uuid, cmdErr := range r.Failed {
+		errs = append(errs, fmt.Errorf("command %s: %w", uuid, cmdErr))
+	}
+	return errors.Join(errs...)
+}
+
+// ReadResources fetches the given resource types. The first call with the
+// SyncToken "*" returns all resources, and the SyncToken is updated after every
+// call so that following calls only return the resources that changed. Use a
+// Store to merge the responses into a local copy of the user's data.
 func (s *Sync) ReadResources(
 	ctx context.Context,
 	resourceTypes []string,
@@ -80,7 +187,7 @@ func (s *Sync) ReadResources(
 	if resourceTypes != nil {
 		s.ResourceTypes = resourceTypes
 	} else {
-		return nil, fmt.Errorf("resourceTypes cannot be nil")
+		return nil, fmt.Errorf("%w: resourceTypes cannot be nil", ErrInvalidArgument)
 	}
 
 	data := url.Values{}
@@ -103,6 +210,11 @@ func (s *Sync) ReadResources(
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
+	// Keep the new token so the next call only returns what changed since.
+	if result.SyncToken != "" {
+		s.SyncToken = result.SyncToken
+	}
+
 	return &result, nil
 }
 
@@ -110,18 +222,271 @@ func (s *Sync) request(
 	ctx context.Context,
 	body io.Reader,
 ) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.todoist.com/api/v1/sync", body)
+	client := s.client
+	if client == nil {
+		client = &Client{BaseURL: DefaultBaseURL}
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		client.BaseURL+"/sync",
+		body,
+	)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+s.APIKey)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Request-Id", newUUID())
 
-	client := &http.Client{}
-	return client.Do(req)
+	return client.do(req)
 }
 
 func (s *Sync) AddCommand(command Command) {
 	s.Commands = append(s.Commands, command)
 }
+
+// WriteCommands sends the queued Commands to the API. Commands that were
+// applied are removed from the queue, while failed commands stay queued so
+// they can be inspected or retried. Temporary IDs referenced by the remaining
+// commands are replaced with the real IDs returned in the temp_id_mapping.
+//
+// Every request carries the SyncToken, and the SyncToken is updated with the
+// token returned by the API, so the next ReadResources does not return the
+// changes made by the commands again. Keep a copy of the previous SyncToken to
+// read them anyway.
+//
+// The API limits the number of commands per request, so the queue is sent in
+// chunks of at most MaxCommandsPerRequest commands, one request at a time and
+// in the order the commands were queued. This keeps every command that creates
+// a temporary ID in the same or an earlier chunk than the commands using it.
+// Each chunk carries the sync token returned for the previous one.
+// Temporary IDs created by earlier chunks are replaced with their real IDs
+// before a chunk is sent, and commands that use the temporary ID of a failed
+// command are not sent but reported as failed. The statuses of all chunks are
+// aggregated into one result.
+//
+// The returned error is only set when a request fails. In that case the result
+// still describes the chunks that were sent, and the unsent commands stay
+// queued. Use SyncWriteResult.Failed or SyncWriteResult.Err to check
+// individual commands.
+func (s *Sync) WriteCommands(ctx context.Context) (*SyncWriteResult, error) {
+	result := &SyncWriteResult{
+		SyncToken:     s.SyncToken,
+		TempIDMapping: map[string]string{},
+		Failed:        map[string]*CommandError{},
+	}
+
+	chunkSize := s.MaxCommandsPerRequest
+	if chunkSize <= 0 {
+		chunkSize = DefaultMaxCommandsPerRequest
+	}
+
+	queue := s.Commands
+	var remaining []Command
+	failedTempIDs := map[string]string{} // Temporary ID to failed command UUID
+
+	for start := 0; start < len(queue); start += chunkSize {
+		var chunk []Command
+		for _, command := range queue[start:min(start+chunkSize, len(queue))] {
+			command.Args = replaceTempIDs(command.Args, result.TempIDMapping)
+			if uuid, ok := dependsOnFailed(command, failedTempIDs); ok {
+				result.Failed[command.UUID] = &CommandError{
+					Message: fmt.Sprintf("depends on failed command %s", uuid),
+				}
+				if command.TempID != "" {
+					failedTempIDs[command.TempID] = command.UUID
+				}
+				remaining = append(remaining, command)
+				continue
+			}
+			chunk = append(chunk, command)
+		}
+		if len(chunk) == 0 {
+			continue
+		}
+
+		writeResp, err := s.writeChunk(ctx, chunk)
+		if err == nil && writeResp.SyncToken != "" {
+			s.SyncToken = writeResp.SyncToken
+			result.SyncToken = writeResp.SyncToken
+		}
+		if err != nil {
+			remaining = append(remaining, chunk...)
+			for _, command := range queue[min(start+chunkSize, len(queue)):] {
+				command.Args = replaceTempIDs(command.Args, result.TempIDMapping)
+				remaining = append(remaining, command)
+			}
+			s.Commands = remaining
+			return result, err
+		}
+
+		for tempID, id := range writeResp.TempIDMapping {
+			result.TempIDMapping[tempID] = id
+		}
+
+		for _, command := range chunk {
+			status, ok := writeResp.SyncStatus[command.UUID]
+			switch {
+			case !ok:
+				result.Failed[command.UUID] = &CommandError{
+					Message: "no status returned for command",
+				}
+			case status.OK():
+				result.Applied = append(result.Applied, command.UUID)
+				continue
+			default:
+				result.Failed[command.UUID] = status.Error
+			}
+
+			if command.TempID != "" {
+				failedTempIDs[command.TempID] = command.UUID
+			}
+			remaining = append(remaining, command)
+		}
+	}
+
+	for i, command := range remaining {
+		remaining[i].Args = replaceTempIDs(command.Args, result.TempIDMapping)
+	}
+	s.Commands = remaining
+
+	return result, nil
+}
+
+// writeChunk sends a single write request with the given commands.
+func (s *Sync) writeChunk(
+	ctx context.Context,
+	commands []Command,
+) (*SyncWriteResponse, error) {
+	encoded, err := json.Marshal(commands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode commands: %w", err)
+	}
+
+	data := url.Values{}
+	data.Set("commands", string(encoded))
+	if s.SyncToken != "" {
+		data.Set("sync_token", s.SyncToken)
+	}
+
+	resp, err := s.request(ctx, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var writeResp SyncWriteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&writeResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return &writeResp, nil
+}
+
+// execute sends commands right away on a queue of their own, without using
+// or changing the queued Commands or the SyncToken. Like WriteCommands, it
+// sends the commands in chunks and replaces temporary IDs across chunks. The
+// returned error joins the errors of the commands that were not applied,
+// which are not kept in any queue.
+func (s *Sync) execute(
+	ctx context.Context,
+	commands ...Command,
+) (*SyncWriteResult, error) {
+	isolated := &Sync{
+		Commands:              slices.Clone(commands),
+		APIKey:                s.APIKey,
+		MaxCommandsPerRequest: s.MaxCommandsPerRequest,
+		client:                s.client,
+	}
+	result, err := isolated.WriteCommands(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for _, command := range commands {
+		if cmdErr, ok := result.Failed[command.UUID]; ok {
+			errs = append(errs, fmt.Errorf("%s: %w", command.Type, cmdErr))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// readAll performs a full sync of the given resource types, without using or
+// changing the SyncToken.
+func (s *Sync) readAll(
+	ctx context.Context,
+	resourceTypes ...string,
+) (*SyncReadResponse, error) {
+	full := &Sync{SyncToken: "*", APIKey: s.APIKey, client: s.client}
+	return full.ReadResources(ctx, resourceTypes)
+}
+
+// dependsOnFailed reports whether the command references the temporary ID of
+// a failed command, and returns the UUID of that command.
+func dependsOnFailed(
+	command Command,
+	failedTempIDs map[string]string,
+) (string, bool) {
+	if len(failedTempIDs) == 0 {
+		return "", false
+	}
+	for _, ref := range idReferences(command.Args) {
+		if uuid, ok := failedTempIDs[ref]; ok {
+			return uuid, true
+		}
+	}
+	return "", false
+}
+
+// replaceTempIDs returns a copy of args where every string, including map
+// keys, that matches a temporary ID in mapping is replaced with the real ID.
+func replaceTempIDs(
+	args map[string]any,
+	mapping map[string]string,
+) map[string]any {
+	if len(mapping) == 0 || args == nil {
+		return args
+	}
+	return replaceTempIDsIn(args, mapping).(map[string]any)
+}
+
+func replaceTempIDsIn(value any, mapping map[string]string) any {
+	switch v := value.(type) {
+	case string:
+		if id, ok := mapping[v]; ok {
+			return id
+		}
+		return v
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = replaceTempIDsIn(s, mapping).(string)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = replaceTempIDsIn(item, mapping)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[replaceTempIDsIn(key, mapping).(string)] = replaceTempIDsIn(
+				item,
+				mapping,
+			)
+		}
+		return out
+	case map[string]int:
+		out := make(map[string]int, len(v))
+		for key, item := range v {
+			out[replaceTempIDsIn(key, mapping).(string)] = item
+		}
+		return out
+	}
+	return value
+}
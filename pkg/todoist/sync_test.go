@@ -0,0 +1,229 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// syncServer is a fake Sync API that records the commands and sync_token of
+// every write request. Commands whose type is in fail are rejected, and every
+// command creating a resource is given the real ID "real-<temp_id>". Writes
+// return token, or "token-<n>" for the nth write if token is empty.
+type syncServer struct {
+	t        *testing.T
+	fail     []string
+	token    string
+	requests [][]Command
+	tokens   []string
+}
+
+func (s *syncServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Errorf("failed to parse form: %v", err)
+	}
+	if r.Form.Get("commands") == "" {
+		fmt.Fprintf(w, `{"sync_token":%q,"full_sync":false}`, s.token)
+		return
+	}
+
+	var commands []Command
+	err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands)
+	if err != nil {
+		s.t.Errorf("failed to decode commands: %v", err)
+	}
+	s.requests = append(s.requests, commands)
+	s.tokens = append(s.tokens, r.Form.Get("sync_token"))
+
+	token := s.token
+	if token == "" {
+		token = fmt.Sprintf("token-%d", len(s.requests))
+	}
+	resp := SyncWriteResponse{
+		SyncToken:     token,
+		SyncStatus:    map[string]CommandStatus{},
+		TempIDMapping: map[string]string{},
+	}
+	for _, command := range commands {
+		if slices.Contains(s.fail, command.Type) {
+			resp.SyncStatus[command.UUID] = CommandStatus{
+				Error: &CommandError{ErrorCode: 20, Message: "failed"},
+			}
+			continue
+		}
+		resp.SyncStatus[command.UUID] = CommandStatus{}
+		if command.TempID != "" {
+			resp.TempIDMapping[command.TempID] = "real-" + command.TempID
+		}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newSyncTestClient(t *testing.T, server *syncServer) *Client {
+	server.t = t
+	return newTestClient(t, server.ServeHTTP)
+}
+
+func TestWriteCommandsSendsChunks(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+	c.Sync.MaxCommandsPerRequest = 2
+	for i := range 5 {
+		c.Sync.AddCommand(NewItemCloseCommand(fmt.Sprint(i)))
+	}
+
+	result, err := c.Sync.WriteCommands(context.Background())
+	if err != nil {
+		t.Fatalf("WriteCommands failed: %v", err)
+	}
+
+	var sizes []int
+	for _, commands := range server.requests {
+		sizes = append(sizes, len(commands))
+	}
+	if !slices.Equal(sizes, []int{2, 2, 1}) {
+		t.Errorf("chunk sizes = %v, want [2 2 1]", sizes)
+	}
+	if len(result.Applied) != 5 || len(result.Failed) != 0 {
+		t.Errorf(
+			"applied %d and failed %d commands, want 5 and 0",
+			len(result.Applied),
+			len(result.Failed),
+		)
+	}
+	if len(c.Sync.Commands) != 0 {
+		t.Errorf("%d commands left in the queue, want 0", len(c.Sync.Commands))
+	}
+}
+
+func TestWriteCommandsReplacesTempIDsAcrossChunks(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+	c.Sync.MaxCommandsPerRequest = 1
+
+	project := NewProjectAddCommand(ProjectAddArgs{Name: "Groceries"})
+	c.Sync.AddCommand(project)
+	c.Sync.AddCommand(NewItemAddCommand(ItemAddArgs{
+		Content:   "Milk",
+		ProjectID: project.TempID,
+	}))
+
+	result, err := c.Sync.WriteCommands(context.Background())
+	if err != nil {
+		t.Fatalf("WriteCommands failed: %v", err)
+	}
+
+	want := "real-" + project.TempID
+	if got := result.TempIDMapping[project.TempID]; got != want {
+		t.Errorf("TempIDMapping[project] = %q, want %q", got, want)
+	}
+	if len(server.requests) != 2 {
+		t.Fatalf("sent %d requests, want 2", len(server.requests))
+	}
+	if got := server.requests[1][0].Args["project_id"]; got != want {
+		t.Errorf("project_id of the second chunk = %v, want %q", got, want)
+	}
+}
+
+func TestWriteCommandsSkipsCommandsDependingOnFailedOnes(t *testing.T) {
+	server := &syncServer{fail: []string{CommandProjectAdd}}
+	c := newSyncTestClient(t, server)
+	c.Sync.MaxCommandsPerRequest = 1
+
+	project := NewProjectAddCommand(ProjectAddArgs{Name: "Groceries"})
+	item := NewItemAddCommand(ItemAddArgs{
+		Content:   "Milk",
+		ProjectID: project.TempID,
+	})
+	other := NewItemCloseCommand("1")
+	c.Sync.AddCommand(project)
+	c.Sync.AddCommand(item)
+	c.Sync.AddCommand(other)
+
+	result, err := c.Sync.WriteCommands(context.Background())
+	if err != nil {
+		t.Fatalf("WriteCommands failed: %v", err)
+	}
+
+	if len(server.requests) != 2 {
+		t.Errorf("sent %d requests, want 2", len(server.requests))
+	}
+	for _, uuid := range []string{project.UUID, item.UUID} {
+		if _, ok := result.Failed[uuid]; !ok {
+			t.Errorf("command %s is not reported as failed", uuid)
+		}
+	}
+	if !slices.Equal(result.Applied, []string{other.UUID}) {
+		t.Errorf("Applied = %v, want [%s]", result.Applied, other.UUID)
+	}
+	if len(c.Sync.Commands) != 2 {
+		t.Errorf("%d commands left in the queue, want 2", len(c.Sync.Commands))
+	}
+	if result.Err() == nil {
+		t.Error("result.Err() = nil, want an error")
+	}
+}
+
+func TestWriteCommandsUpdatesSyncToken(t *testing.T) {
+	server := &syncServer{token: "after-write"}
+	c := newSyncTestClient(t, server)
+	c.Sync.SyncToken = "last-read"
+	c.Sync.AddCommand(NewItemCloseCommand("1"))
+
+	result, err := c.Sync.WriteCommands(context.Background())
+	if err != nil {
+		t.Fatalf("WriteCommands failed: %v", err)
+	}
+	if c.Sync.SyncToken != "after-write" {
+		t.Errorf("SyncToken = %q, want %q", c.Sync.SyncToken, "after-write")
+	}
+	if result.SyncToken != "after-write" {
+		t.Errorf("result.SyncToken = %q, want %q", result.SyncToken, "after-write")
+	}
+}
+
+func TestWriteCommandsCarriesSyncTokenAcrossChunks(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+	c.Sync.SyncToken = "last-read"
+	c.Sync.MaxCommandsPerRequest = 1
+	for i := range 3 {
+		c.Sync.AddCommand(NewItemCloseCommand(fmt.Sprint(i)))
+	}
+
+	result, err := c.Sync.WriteCommands(context.Background())
+	if err != nil {
+		t.Fatalf("WriteCommands failed: %v", err)
+	}
+	want := []string{"last-read", "token-1", "token-2"}
+	if !slices.Equal(server.tokens, want) {
+		t.Errorf("sent sync tokens %v, want %v", server.tokens, want)
+	}
+	if c.Sync.SyncToken != "token-3" || result.SyncToken != "token-3" {
+		t.Errorf(
+			"SyncToken = %q and result.SyncToken = %q, want %q",
+			c.Sync.SyncToken,
+			result.SyncToken,
+			"token-3",
+		)
+	}
+}
+
+func TestReadResourcesKeepsTokenWhenNoneIsReturned(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"full_sync":false}`)
+	})
+	c.Sync.SyncToken = "last-read"
+
+	_, err := c.Sync.ReadResources(context.Background(), []string{"all"})
+	if err != nil {
+		t.Fatalf("ReadResources failed: %v", err)
+	}
+	if c.Sync.SyncToken != "last-read" {
+		t.Errorf("SyncToken = %q, want %q", c.Sync.SyncToken, "last-read")
+	}
+}
@@ -4,38 +4,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"time"
 )
 
 // Task represents a task in Todoist.
 // The Task struct contains all the fields returned by the API.
 type Task struct {
-	UserID         string          `json:"user_id"`
-	ID             string          `json:"id"`
-	ProjectID      string          `json:"project_id"`
-	SectionID      *string         `json:"section_id"`
-	ParentID       *string         `json:"parent_id"`
-	AddedByUID     *string         `json:"added_by_uid"`
-	AssignedByUID  *string         `json:"assigned_by_uid"`
-	ResponsibleUID *string         `json:"responsible_uid"`
-	Labels         []string        `json:"labels"`
-	Deadline       *map[string]any `json:"deadline"`
-	Duration       *map[string]any `json:"duration"`
-	Checked        bool            `json:"checked"`
-	IsDeleted      bool            `json:"is_deleted"`
-	AddedAt        *string         `json:"added_at"`
-	CompletedAt    *string         `json:"completed_at"`
-	UpdatedAt      *string         `json:"updated_at"`
-	Due            *map[string]any `json:"due"`
-	Priority       int             `json:"priority"`
-	ChildOrder     int             `json:"child_order"`
-	Content        string          `json:"content"`
-	Description    string          `json:"description"`
-	NoteCount      int             `json:"note_count"`
-	DayOrder       int             `json:"day_order"`
-	IsCollapsed    bool            `json:"is_collapsed"`
-}
-
-// TaskOptions represents the body parameters for creating or updating a task.
+	UserID         string     `json:"user_id"`
+	ID             string     `json:"id"`
+	ProjectID      string     `json:"project_id"`
+	SectionID      *string    `json:"section_id"`
+	ParentID       *string    `json:"parent_id"`
+	AddedByUID     *string    `json:"added_by_uid"`
+	AssignedByUID  *string    `json:"assigned_by_uid"`
+	ResponsibleUID *string    `json:"responsible_uid"`
+	Labels         []string   `json:"labels"`
+	Deadline       *Deadline  `json:"deadline"`
+	Duration       *Duration  `json:"duration"`
+	Checked        bool       `json:"checked"`
+	IsDeleted      bool       `json:"is_deleted"`
+	AddedAt        *Timestamp `json:"added_at"`
+	CompletedAt    *Timestamp `json:"completed_at"`
+	UpdatedAt      *Timestamp `json:"updated_at"`
+	Due            *Due       `json:"due"`
+	Priority       int        `json:"priority"`
+	ChildOrder     int        `json:"child_order"`
+	Content        string     `json:"content"`
+	Description    string     `json:"description"`
+	NoteCount      int        `json:"note_count"`
+	DayOrder       int        `json:"day_order"`
+	IsCollapsed    bool       `json:"is_collapsed"`
+}
+
+// TaskOptions represents the body parameters for creating a task.
 type TaskOptions struct {
 	Content      string   `json:"content,omitempty"`
 	Description  string   `json:"description,omitempty"`
@@ -56,13 +58,45 @@ type TaskOptions struct {
 	DeadlineLang string   `json:"deadline_lang,omitempty"`
 }
 
+// TaskUpdate represents the body parameters for updating a task. Only the
+// fields that are set are updated, so fields can be cleared by setting them to
+// their zero value or to null. To remove the due date, set DueString to
+// "no date".
+//
+// Example:
+//
+//	client.UpdateTask(ctx, id, &todoist.TaskUpdate{
+//	 Labels:     todoist.Some([]string{}),
+//	 Priority:   todoist.Some(1),
+//	 AssigneeID: todoist.Null[string](),
+//	})
+type TaskUpdate struct {
+	Content      Optional[string]       `json:"content"`
+	Description  Optional[string]       `json:"description"`
+	Labels       Optional[[]string]     `json:"labels"`
+	Priority     Optional[int]          `json:"priority"`
+	DueString    Optional[string]       `json:"due_string"`
+	DueDate      Optional[string]       `json:"due_date"`
+	DueDateTime  Optional[string]       `json:"due_datetime"`
+	DueLang      Optional[string]       `json:"due_lang"`
+	AssigneeID   Optional[string]       `json:"assignee_id"`
+	Duration     Optional[int]          `json:"duration"` // If duration is set, duration_unit must also be set
+	DurationUnit Optional[DurationUnit] `json:"duration_unit"`
+	DeadlineDate Optional[string]       `json:"deadline_date"`
+	DeadlineLang Optional[string]       `json:"deadline_lang"`
+}
+
+func (u TaskUpdate) MarshalJSON() ([]byte, error) {
+	return marshalUpdate(u)
+}
+
 // TaskFilters represents the query parameters for filtering tasks.
 type TaskFilters struct {
 	ProjectID string `json:"project_id,omitempty"`
 	SectionID string `json:"section_id,omitempty"`
 	ParentID  string `json:"parent_id,omitempty"`
 	Label     string `json:"label,omitempty"`
-	Filter    string `json:"filter,omitempty"`
+	Filter    string `json:"filter,omitempty"` // See FilterQuery to build filters
 	Lang      string `json:"lang,omitempty"`
 	IDs       string `json:"ids,omitempty"` //A list of the task IDs to retrieve, this should be a comma separated list
 	PaginationFilters
@@ -113,38 +147,327 @@ func (c *Client) GetTasks(
 	return pagiResp.Results, pagiResp.NextCursor, nil
 }
 
-// Tasks Completed By Completion Date
+// AllTasks returns an iterator over all active user tasks matching the
+// filters, requesting further pages as needed. The Cursor in filters is used
+// as the starting point.
+func (c *Client) AllTasks(
+	ctx context.Context,
+	filters *TaskFilters,
+) iter.Seq2[Task, error] {
+	var f TaskFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]Task, *string, error) {
+			f.Cursor = cursor
+			return c.GetTasks(ctx, &f)
+		},
+	)
+}
+
+// Maximum ranges between Since and Until accepted by the completed tasks
+// endpoints. The API allows 3 months by completion date, which is rounded
+// down to 90 days so that it holds for any month, and 6 weeks by due date.
+const (
+	MaxCompletedByCompletionDateRange = 90 * 24 * time.Hour
+	MaxCompletedByDueDateRange        = 6 * 7 * 24 * time.Hour
+)
+
+// CompletedTaskFilters represents the query parameters for getting completed
+// tasks. Since and Until are required, and the other filters are optional.
+type CompletedTaskFilters struct {
+	Since       time.Time `json:"-"`
+	Until       time.Time `json:"-"`
+	WorkspaceID string    `json:"workspace_id,omitempty"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	SectionID   string    `json:"section_id,omitempty"`
+	ParentID    string    `json:"parent_id,omitempty"`
+	FilterQuery string    `json:"filter_query,omitempty"`
+	FilterLang  string    `json:"filter_lang,omitempty"`
+	PaginationFilters
+}
+
+// completedTasksQuery adds the formatted Since and Until to the filters.
+type completedTasksQuery struct {
+	Since string `json:"since"`
+	Until string `json:"until"`
+	CompletedTaskFilters
+}
+
+// completedTasksResponse is the response of the completed tasks endpoints,
+// which use items instead of results.
+type completedTasksResponse struct {
+	Items      []Task  `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// GetCompletedByCompletionDate returns the tasks completed between
+// filters.Since and filters.Until and a cursor for pagination. The cursor is
+// nil if there are no more pages to return. The range can be at most
+// MaxCompletedByCompletionDateRange long; use AllCompletedByCompletionDate to
+// get larger ranges.
+func (c *Client) GetCompletedByCompletionDate(
+	ctx context.Context,
+	filters *CompletedTaskFilters,
+) ([]Task, *string, error) {
+	return c.getCompletedTasks(
+		ctx,
+		"/tasks/completed/by_completion_date",
+		MaxCompletedByCompletionDateRange,
+		filters,
+	)
+}
+
+// GetCompletedByDueDate returns the completed tasks that were due between
+// filters.Since and filters.Until and a cursor for pagination. The cursor is
+// nil if there are no more pages to return. The range can be at most
+// MaxCompletedByDueDateRange long; use AllCompletedByDueDate to get larger
+// ranges.
+func (c *Client) GetCompletedByDueDate(
+	ctx context.Context,
+	filters *CompletedTaskFilters,
+) ([]Task, *string, error) {
+	return c.getCompletedTasks(
+		ctx,
+		"/tasks/completed/by_due_date",
+		MaxCompletedByDueDateRange,
+		filters,
+	)
+}
+
+// AllCompletedByCompletionDate returns an iterator over all tasks completed
+// between filters.Since and filters.Until. Ranges larger than
+// MaxCompletedByCompletionDateRange are split into several requests.
+func (c *Client) AllCompletedByCompletionDate(
+	ctx context.Context,
+	filters *CompletedTaskFilters,
+) iter.Seq2[Task, error] {
+	return c.allCompletedTasks(
+		ctx,
+		MaxCompletedByCompletionDateRange,
+		filters,
+		c.GetCompletedByCompletionDate,
+	)
+}
+
+// AllCompletedByDueDate returns an iterator over all completed tasks that
+// were due between filters.Since and filters.Until. Ranges larger than
+// MaxCompletedByDueDateRange are split into several requests.
+func (c *Client) AllCompletedByDueDate(
+	ctx context.Context,
+	filters *CompletedTaskFilters,
+) iter.Seq2[Task, error] {
+	return c.allCompletedTasks(
+		ctx,
+		MaxCompletedByDueDateRange,
+		filters,
+		c.GetCompletedByDueDate,
+	)
+}
+
+func (c *Client) getCompletedTasks(
+	ctx context.Context,
+	endpoint string,
+	maxRange time.Duration,
+	filters *CompletedTaskFilters,
+) ([]Task, *string, error) {
+	if filters == nil || filters.Since.IsZero() || filters.Until.IsZero() {
+		return nil, nil, fmt.Errorf(
+			"%w: since and until are required",
+			ErrInvalidArgument,
+		)
+	}
+	if filters.Until.Before(filters.Since) {
+		return nil, nil, fmt.Errorf(
+			"%w: until is before since",
+			ErrInvalidArgument,
+		)
+	}
+	if filters.Until.Sub(filters.Since) > maxRange {
+		return nil, nil, fmt.Errorf(
+			"%w: range between since and until is longer than %s",
+			ErrInvalidArgument,
+			maxRange,
+		)
+	}
+
+	query := completedTasksQuery{
+		Since:                filters.Since.UTC().Format(time.RFC3339),
+		Until:                filters.Until.UTC().Format(time.RFC3339),
+		CompletedTaskFilters: *filters,
+	}
+	res, err := c.request(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+	defer res.Body.Close()
+
+	var resp completedTasksResponse
+	err = json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Items, resp.NextCursor, nil
+}
+
+// allCompletedTasks splits the range of filters into windows of at most
+// maxRange and paginates through each of them with get. Tasks returned by two
+// adjacent windows are only yielded once.
+func (c *Client) allCompletedTasks(
+	ctx context.Context,
+	maxRange time.Duration,
+	filters *CompletedTaskFilters,
+	get func(context.Context, *CompletedTaskFilters) ([]Task, *string, error),
+) iter.Seq2[Task, error] {
+	var f CompletedTaskFilters
+	if filters != nil {
+		f = *filters
+	}
+	return func(yield func(Task, error) bool) {
+		if f.Since.IsZero() || f.Until.IsZero() || f.Until.Before(f.Since) {
+			// Let get report the invalid range.
+			_, _, err := get(ctx, &f)
+			yield(Task{}, err)
+			return
+		}
+
+		seen := map[string]bool{}
+		for since := f.Since; ; since = since.Add(maxRange) {
+			window := f
+			window.Since = since
+			window.Until = since.Add(maxRange)
+			if window.Until.After(f.Until) {
+				window.Until = f.Until
+			}
 
-// Tasks Completed By Due Date
+			tasks := paginate(
+				ctx,
+				window.Cursor,
+				func(cursor string) ([]Task, *string, error) {
+					window.Cursor = cursor
+					return get(ctx, &window)
+				},
+			)
+			for task, err := range tasks {
+				if err != nil {
+					yield(task, err)
+					return
+				}
+				if seen[task.ID] {
+					continue
+				}
+				seen[task.ID] = true
+				if !yield(task, nil) {
+					return
+				}
+			}
 
-// Get Tasks By Filter
+			if !window.Until.Before(f.Until) {
+				return
+			}
+			// The starting cursor only applies to the first window.
+			f.Cursor = ""
+		}
+	}
+}
+
+// taskFilterQuery represents the query parameters of the tasks filter
+// endpoint.
+type taskFilterQuery struct {
+	Query string `json:"query"`
+	Lang  string `json:"lang,omitempty"`
+	PaginationFilters
+}
+
+// GetTasksByFilter returns all active tasks matching a query in the Todoist
+// filter syntax, which can be built with FilterQuery. The lang parameter is
+// the language of the query and is optional.
+func (c *Client) GetTasksByFilter(
+	ctx context.Context,
+	query string,
+	lang string,
+) ([]Task, error) {
+	return CollectAll(c.AllTasksByFilter(ctx, query, lang), 0)
+}
+
+// AllTasksByFilter returns an iterator over all active tasks matching a query
+// in the Todoist filter syntax, requesting further pages as needed.
+func (c *Client) AllTasksByFilter(
+	ctx context.Context,
+	query string,
+	lang string,
+) iter.Seq2[Task, error] {
+	q := taskFilterQuery{Query: query, Lang: lang}
+	return paginate(
+		ctx,
+		"",
+		func(cursor string) ([]Task, *string, error) {
+			q.Cursor = cursor
+			return c.getTasksByFilter(ctx, &q)
+		},
+	)
+}
+
+func (c *Client) getTasksByFilter(
+	ctx context.Context,
+	query *taskFilterQuery,
+) ([]Task, *string, error) {
+	if query.Query == "" {
+		return nil, nil, fmt.Errorf("%w: query is required", ErrInvalidArgument)
+	}
+
+	res, err := c.request(ctx, "GET", "/tasks/filter", nil, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tasks by filter: %w", err)
+	}
+	defer res.Body.Close()
+
+	var pagiResp PaginationResponse[Task]
+	err = json.NewDecoder(res.Body).Decode(&pagiResp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pagiResp.Results, pagiResp.NextCursor, nil
+}
 
 // QuickAddTask creates a new task using the quick add feature. This is what
 // Todoist uses to create tasks with natural language processing. The text
-// parameter is the text of the task to create. The options parameter is
-// optional if you want to set additional options for the task.
+// parameter is the text of the task to create, e.g. "Call Jane tomorrow at
+// 5pm #Work @phone". The options parameter is optional; set its Meta field
+// to get how the text was interpreted in QuickAddResult.Meta.
 func (c *Client) QuickAddTask(
 	ctx context.Context,
 	text string,
-	options *TaskOptions,
-) (*Task, error) {
+	options *QuickAddOptions,
+) (*QuickAddResult, error) {
+	if text == "" {
+		return nil, fmt.Errorf("%w: text is required", ErrInvalidArgument)
+	}
+
 	body := struct {
 		Text string `json:"text"`
+		QuickAddOptions
 	}{
 		Text: text,
 	}
+	if options != nil {
+		body.QuickAddOptions = *options
+	}
 	res, err := c.request(ctx, "POST", "/tasks/quick", body, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to quick add task: %w", err)
 	}
 	defer res.Body.Close()
 
-	var task Task
-	err = json.NewDecoder(res.Body).Decode(&task)
+	var result QuickAddResult
+	err = json.NewDecoder(res.Body).Decode(&result)
 	if err != nil {
 		return nil, err
 	}
-	return &task, nil
+	return &result, nil
 }
 
 // ReopenTask reopens a task that has been completed. The taskID parameter is
@@ -183,7 +506,117 @@ func (c *Client) CloseTask(ctx context.Context, taskID string) error {
 	return nil
 }
 
-// Move Task
+// MoveTarget is where a task is moved to. Exactly one of the fields must be
+// set.
+type MoveTarget struct {
+	ProjectID string `json:"project_id,omitempty"`
+	SectionID string `json:"section_id,omitempty"`
+	ParentID  string `json:"parent_id,omitempty"`
+}
+
+// validate checks that exactly one destination is set.
+func (t MoveTarget) validate() error {
+	set := 0
+	for _, id := range []string{t.ProjectID, t.SectionID, t.ParentID} {
+		if id != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf(
+			"%w: exactly one of project_id, section_id or parent_id must be set",
+			ErrInvalidArgument,
+		)
+	}
+	return nil
+}
+
+// MoveTask moves a task, along with its subtasks, to another project, section
+// or parent task and returns the moved task.
+func (c *Client) MoveTask(
+	ctx context.Context,
+	taskID string,
+	target MoveTarget,
+) (*Task, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("%w: task ID is required", ErrInvalidArgument)
+	}
+	if err := target.validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := c.request(
+		ctx,
+		"POST",
+		fmt.Sprintf("/tasks/%s/move", taskID),
+		target,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move task: %w", err)
+	}
+	defer res.Body.Close()
+
+	var task Task
+	err = json.NewDecoder(res.Body).Decode(&task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// MoveTasks moves several tasks to the same project, section or parent task
+// with item_move commands. Tasks whose parent is also in tasks are moved
+// along with it, so whole subtrees keep their structure. The other tasks are
+// moved in the order they are given, since their child orders cannot be
+// compared when they come from different parents.
+//
+// The commands are sent on their own, without the commands queued in the
+// Sync. Failed moves are reported by the returned error and are not queued
+// again.
+func (c *Client) MoveTasks(
+	ctx context.Context,
+	tasks []Task,
+	target MoveTarget,
+) (*SyncWriteResult, error) {
+	if err := target.validate(); err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return &SyncWriteResult{}, nil
+	}
+
+	byID := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	var roots []Task
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			if _, ok := byID[*task.ParentID]; ok {
+				continue // Moved along with its parent
+			}
+		}
+		roots = append(roots, task)
+	}
+
+	commands := make([]Command, len(roots))
+	for i, task := range roots {
+		commands[i] = NewItemMoveCommand(ItemMoveArgs{
+			ID:        task.ID,
+			ProjectID: target.ProjectID,
+			SectionID: target.SectionID,
+			ParentID:  target.ParentID,
+		})
+	}
+
+	result, err := c.Sync.execute(ctx, commands...)
+	if err != nil {
+		return result, fmt.Errorf("failed to move tasks: %w", err)
+	}
+	return result, nil
+}
 
 // GetTask returns a task related to the given taskID. The taskID parameter
 // is the ID of the task to get. The taskID parameter is required.
@@ -208,17 +641,21 @@ func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
 	return &task, nil
 }
 
-// UpdateTask updates a task with the given taskID with the given TaskOptions.
+// UpdateTask updates the fields of a task that are set in update.
 func (c *Client) UpdateTask(
 	ctx context.Context,
 	taskID string,
-	options *TaskOptions,
+	update *TaskUpdate,
 ) (*Task, error) {
+	if update == nil {
+		return nil, fmt.Errorf("%w: update is required", ErrInvalidArgument)
+	}
+
 	res, err := c.request(
 		ctx,
 		"POST",
 		fmt.Sprintf("/tasks/%s", taskID),
-		options,
+		update,
 		nil,
 	)
 	if err != nil {
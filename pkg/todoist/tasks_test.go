@@ -0,0 +1,211 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMoveTasksLeavesQueueAlone(t *testing.T) {
+	server := &syncServer{fail: []string{CommandItemMove}}
+	c := newSyncTestClient(t, server)
+	queued := NewItemCloseCommand("queued")
+	c.Sync.AddCommand(queued)
+
+	parentID := "1"
+	tasks := []Task{
+		{ID: "2", ChildOrder: 2},
+		{ID: "1", ChildOrder: 1},
+		{ID: "3", ParentID: &parentID}, // Moved along with its parent
+	}
+	_, err := c.MoveTasks(context.Background(), tasks, MoveTarget{
+		ProjectID: "p",
+	})
+	if err == nil {
+		t.Fatal("err = nil, want the errors of the failed moves")
+	}
+
+	if len(server.requests) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(server.requests))
+	}
+	var ids []any
+	for _, command := range server.requests[0] {
+		if command.Type != CommandItemMove {
+			t.Errorf("sent a %s command, want only item_move", command.Type)
+		}
+		ids = append(ids, command.Args["id"])
+	}
+	if len(ids) != 2 || ids[0] != "2" || ids[1] != "1" {
+		t.Errorf("moved tasks %v, want [2 1]", ids)
+	}
+	if len(c.Sync.Commands) != 1 || c.Sync.Commands[0].UUID != queued.UUID {
+		t.Errorf("queue = %v, want only the command queued before", c.Sync.Commands)
+	}
+}
+
+func TestMoveTasksWithoutTasks(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	_, err := c.MoveTasks(context.Background(), nil, MoveTarget{ProjectID: "p"})
+	if err != nil {
+		t.Fatalf("MoveTasks failed: %v", err)
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("sent %d requests, want 0", len(server.requests))
+	}
+}
+
+func TestMoveTasksKeepsGivenOrder(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	sectionID := "s"
+	tasks := []Task{
+		{ID: "1", ProjectID: "a", ChildOrder: 5},
+		{ID: "2", ProjectID: "b", ChildOrder: 1},
+		{ID: "3", ProjectID: "a", SectionID: &sectionID, ChildOrder: 3},
+	}
+	_, err := c.MoveTasks(context.Background(), tasks, MoveTarget{
+		ProjectID: "p",
+	})
+	if err != nil {
+		t.Fatalf("MoveTasks failed: %v", err)
+	}
+
+	var ids []any
+	for _, command := range server.requests[0] {
+		ids = append(ids, command.Args["id"])
+	}
+	if len(ids) != 3 || ids[0] != "1" || ids[1] != "2" || ids[2] != "3" {
+		t.Errorf("moved tasks %v, want [1 2 3]", ids)
+	}
+}
+
+func TestMoveTaskRequiresID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := c.MoveTask(context.Background(), "", MoveTarget{ProjectID: "p"})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("err = %v, want ErrInvalidArgument", err)
+	}
+}
+
+// completedWindow is the range of a request for completed tasks.
+type completedWindow struct {
+	since, until time.Time
+}
+
+// newCompletedTestClient returns a client serving the completed tasks
+// endpoints with tasks, and records the windows of the requests. Each task is
+// returned by every window that contains its completion time, bounds
+// included, one task per page.
+func newCompletedTestClient(
+	t *testing.T,
+	tasks map[string]time.Time,
+	windows *[]completedWindow,
+) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		since, err := time.Parse(time.RFC3339, query.Get("since"))
+		if err != nil {
+			t.Errorf("invalid since: %v", err)
+		}
+		until, err := time.Parse(time.RFC3339, query.Get("until"))
+		if err != nil {
+			t.Errorf("invalid until: %v", err)
+		}
+		if query.Get("cursor") == "" {
+			*windows = append(*windows, completedWindow{since, until})
+		}
+
+		var ids []string
+		for id, completed := range tasks {
+			if !completed.Before(since) && !completed.After(until) {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+		ids = slices.DeleteFunc(ids, func(id string) bool {
+			return id <= query.Get("cursor")
+		})
+		if len(ids) == 0 {
+			fmt.Fprint(w, `{"items":[],"next_cursor":null}`)
+			return
+		}
+		next := "null"
+		if len(ids) > 1 {
+			next = fmt.Sprintf("%q", ids[0])
+		}
+		fmt.Fprintf(w, `{"items":[{"id":%q}],"next_cursor":%s}`, ids[0], next)
+	})
+}
+
+func TestAllCompletedByCompletionDateSplitsRange(t *testing.T) {
+	day := 24 * time.Hour
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	boundary := since.Add(MaxCompletedByCompletionDateRange)
+	tasks := map[string]time.Time{
+		"a": since.Add(day),
+		"b": since.Add(2 * day),
+		"c": boundary, // Returned by the first two windows
+		"d": boundary.Add(day),
+		"e": since.Add(199 * day),
+	}
+
+	var windows []completedWindow
+	c := newCompletedTestClient(t, tasks, &windows)
+	var ids []string
+	for task, err := range c.AllCompletedByCompletionDate(
+		context.Background(),
+		&CompletedTaskFilters{Since: since, Until: since.Add(200 * day)},
+	) {
+		if err != nil {
+			t.Fatalf("AllCompletedByCompletionDate failed: %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+
+	if want := []string{"a", "b", "c", "d", "e"}; !slices.Equal(ids, want) {
+		t.Errorf("tasks = %v, want %v", ids, want)
+	}
+	want := []completedWindow{
+		{since, boundary},
+		{boundary, boundary.Add(MaxCompletedByCompletionDateRange)},
+		{
+			boundary.Add(MaxCompletedByCompletionDateRange),
+			since.Add(200 * day),
+		},
+	}
+	if !slices.Equal(windows, want) {
+		t.Errorf("windows = %v, want %v", windows, want)
+	}
+}
+
+func TestAllCompletedByDueDateSplitsRange(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(10 * 7 * 24 * time.Hour)
+
+	var windows []completedWindow
+	c := newCompletedTestClient(t, nil, &windows)
+	for _, err := range c.AllCompletedByDueDate(
+		context.Background(),
+		&CompletedTaskFilters{Since: since, Until: until},
+	) {
+		if err != nil {
+			t.Fatalf("AllCompletedByDueDate failed: %v", err)
+		}
+	}
+
+	boundary := since.Add(MaxCompletedByDueDateRange)
+	want := []completedWindow{{since, boundary}, {boundary, until}}
+	if !slices.Equal(windows, want) {
+		t.Errorf("windows = %v, want %v", windows, want)
+	}
+}
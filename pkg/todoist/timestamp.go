@@ -0,0 +1,96 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the formats in which the API sends timestamps, tried
+// in order. Timestamps without a zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano, // 2025-01-02T15:04:05.123456Z, 2025-01-02T15:04:05+01:00
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"Mon 02 Jan 2006 15:04:05 -0700", // Used by older versions of the API
+	dateLayout,
+}
+
+// Timestamp is a point in time sent by the API, such as the time a task was
+// added or completed. It embeds time.Time, so it can be compared and
+// formatted directly.
+//
+// Timestamp fields are pointers, which are nil when the API sends null or
+// leaves the field out.
+//
+// A Timestamp remembers the text it was decoded from and encodes back to it
+// as long as its time is not changed. The zero Timestamp encodes as null.
+type Timestamp struct {
+	time.Time
+
+	raw string
+}
+
+// NewTimestamp returns a Timestamp for t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses a timestamp in any of the formats used by the API.
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t, raw: s}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to decode timestamp: %w", err)
+	}
+	if s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	ts, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = ts
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	if t.raw != "" {
+		if raw, err := ParseTimestamp(t.raw); err == nil && raw.Equal(t.Time) {
+			return json.Marshal(t.raw)
+		}
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
+
+// String returns the text the timestamp was decoded from, or the time in
+// RFC 3339 format.
+func (t Timestamp) String() string {
+	if t.raw != "" {
+		return t.raw
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,40 @@
+package todoist
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampFields(t *testing.T) {
+	var comments []Comment
+	err := json.Unmarshal([]byte(`[
+		{"id": "1", "posted_at": "2024-03-10T12:00:00.000000Z"},
+		{"id": "2", "posted_at": null},
+		{"id": "3"}
+	]`), &comments)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	want := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	if comments[0].PostedAt == nil || !comments[0].PostedAt.Equal(want) {
+		t.Errorf("PostedAt = %v, want %v", comments[0].PostedAt, want)
+	}
+	for _, comment := range comments[1:] {
+		if comment.PostedAt != nil {
+			t.Errorf("comment %s: PostedAt = %v, want nil", comment.ID, comment.PostedAt)
+		}
+	}
+
+	data, err := json.Marshal(comments[0].PostedAt)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	if string(data) != `"2024-03-10T12:00:00.000000Z"` {
+		t.Errorf("encoded %s, want the decoded text", data)
+	}
+	if compareComments(comments[1], comments[0]) >= 0 {
+		t.Error("comment without PostedAt does not sort first")
+	}
+}
@@ -0,0 +1,368 @@
+package todoist
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// TreeNode is an item of a Tree along with its place in the hierarchy.
+type TreeNode[T any] struct {
+	Item     T
+	Parent   *TreeNode[T] // nil for roots
+	Children []*TreeNode[T]
+	Depth    int // 0 for roots
+}
+
+// IsLeaf reports whether the node has no children.
+func (n *TreeNode[T]) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Subtree returns an iterator over the node and all of its descendants, in
+// depth-first order.
+func (n *TreeNode[T]) Subtree() iter.Seq[*TreeNode[T]] {
+	return func(yield func(*TreeNode[T]) bool) {
+		walkDepthFirst(n, nil, yield)
+	}
+}
+
+// walkDepthFirst yields n and its descendants in pre-order. Children of the
+// nodes for which skip returns true are not visited. It returns false when
+// yield stopped the iteration.
+func walkDepthFirst[T any](
+	n *TreeNode[T],
+	skip func(T) bool,
+	yield func(*TreeNode[T]) bool,
+) bool {
+	if !yield(n) {
+		return false
+	}
+	if skip != nil && skip(n.Item) {
+		return true
+	}
+	for _, child := range n.Children {
+		if !walkDepthFirst(child, skip, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tree is a hierarchy of items, such as tasks and their subtasks, built from
+// a flat list where every item refers to its parent by ID. Siblings are kept
+// in their display order.
+type Tree[T any] struct {
+	// Roots are the items without a parent, followed by the orphans.
+	Roots []*TreeNode[T]
+
+	// Orphans are the items whose parent is not in the list, e.g. because it
+	// was filtered out or deleted. They are also included in Roots.
+	Orphans []*TreeNode[T]
+
+	nodes     map[string]*TreeNode[T]
+	collapsed func(T) bool
+}
+
+// newTree builds a tree from items. id and parent return the ID of an item
+// and of its parent, or "" for roots. Roots and orphans are sorted with
+// compareRoots, and the children of a node with compare.
+func newTree[T any](
+	items []T,
+	id func(T) string,
+	parent func(T) string,
+	compareRoots func(a, b T) int,
+	compare func(a, b T) int,
+	collapsed func(T) bool,
+) *Tree[T] {
+	t := &Tree[T]{
+		nodes:     make(map[string]*TreeNode[T], len(items)),
+		collapsed: collapsed,
+	}
+	for _, item := range items {
+		t.nodes[id(item)] = &TreeNode[T]{Item: item}
+	}
+
+	var roots, orphans []*TreeNode[T]
+	for _, item := range items {
+		node := t.nodes[id(item)]
+		parentID := parent(item)
+		switch p, ok := t.nodes[parentID]; {
+		case parentID == "":
+			roots = append(roots, node)
+		case !ok || createsCycle(t.nodes, parent, id(item), parentID):
+			orphans = append(orphans, node)
+		default:
+			node.Parent = p
+			p.Children = append(p.Children, node)
+		}
+	}
+
+	compareRootNodes := func(a, b *TreeNode[T]) int {
+		return compareRoots(a.Item, b.Item)
+	}
+	compareNodes := func(a, b *TreeNode[T]) int {
+		return compare(a.Item, b.Item)
+	}
+	slices.SortStableFunc(roots, compareRootNodes)
+	slices.SortStableFunc(orphans, compareRootNodes)
+	t.Orphans = orphans
+	t.Roots = append(roots, orphans...)
+
+	for _, root := range t.Roots {
+		for node := range root.Subtree() {
+			if node.Parent != nil {
+				node.Depth = node.Parent.Depth + 1
+			}
+			slices.SortStableFunc(node.Children, compareNodes)
+		}
+	}
+	return t
+}
+
+// createsCycle reports whether following the parents from parentID leads
+// back to id.
+func createsCycle[T any](
+	nodes map[string]*TreeNode[T],
+	parent func(T) string,
+	id string,
+	parentID string,
+) bool {
+	seen := map[string]bool{}
+	for current := parentID; current != "" && !seen[current]; {
+		if current == id {
+			return true
+		}
+		seen[current] = true
+		node, ok := nodes[current]
+		if !ok {
+			return false
+		}
+		current = parent(node.Item)
+	}
+	return false
+}
+
+// Node returns the node of the item with the given ID.
+func (t *Tree[T]) Node(id string) (*TreeNode[T], bool) {
+	node, ok := t.nodes[id]
+	return node, ok
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree[T]) Len() int {
+	return len(t.nodes)
+}
+
+// All returns an iterator over every node in depth-first order, as the items
+// are displayed when everything is expanded.
+func (t *Tree[T]) All() iter.Seq[*TreeNode[T]] {
+	return func(yield func(*TreeNode[T]) bool) {
+		for _, root := range t.Roots {
+			if !walkDepthFirst(root, nil, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Visible returns an iterator over the nodes in depth-first order, skipping
+// the descendants of collapsed items.
+func (t *Tree[T]) Visible() iter.Seq[*TreeNode[T]] {
+	return func(yield func(*TreeNode[T]) bool) {
+		for _, root := range t.Roots {
+			if !walkDepthFirst(root, t.collapsed, yield) {
+				return
+			}
+		}
+	}
+}
+
+// BreadthFirst returns an iterator over every node, level by level.
+func (t *Tree[T]) BreadthFirst() iter.Seq[*TreeNode[T]] {
+	return func(yield func(*TreeNode[T]) bool) {
+		queue := slices.Clone(t.Roots)
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if !yield(node) {
+				return
+			}
+			queue = append(queue, node.Children...)
+		}
+	}
+}
+
+// Ancestors returns the ancestors of the item with the given ID, starting
+// with its parent. It returns nil for roots and unknown IDs.
+func (t *Tree[T]) Ancestors(id string) []T {
+	node, ok := t.nodes[id]
+	if !ok {
+		return nil
+	}
+
+	var ancestors []T
+	for p := node.Parent; p != nil; p = p.Parent {
+		ancestors = append(ancestors, p.Item)
+	}
+	return ancestors
+}
+
+// Render draws the tree as text, one item per line, using label to describe
+// each item.
+//
+// Example output:
+//
+//	Groceries
+//	├── Milk
+//	└── Fruit
+//	    └── Apples
+func (t *Tree[T]) Render(label func(T) string) string {
+	return t.render(label, nil)
+}
+
+// RenderVisible draws the tree like Render, but hides the descendants of
+// collapsed items.
+func (t *Tree[T]) RenderVisible(label func(T) string) string {
+	return t.render(label, t.collapsed)
+}
+
+func (t *Tree[T]) render(label func(T) string, skip func(T) bool) string {
+	var b strings.Builder
+	var renderNode func(node *TreeNode[T], prefix string, last bool)
+	renderNode = func(node *TreeNode[T], prefix string, last bool) {
+		childPrefix := prefix
+		if node.Parent != nil {
+			b.WriteString(prefix)
+			if last {
+				b.WriteString("└── ")
+				childPrefix += "    "
+			} else {
+				b.WriteString("├── ")
+				childPrefix += "│   "
+			}
+		}
+		b.WriteString(label(node.Item))
+		b.WriteByte('\n')
+
+		if skip != nil && skip(node.Item) {
+			return
+		}
+		for i, child := range node.Children {
+			renderNode(child, childPrefix, i == len(node.Children)-1)
+		}
+	}
+
+	for _, root := range t.Roots {
+		renderNode(root, "", true)
+	}
+	return b.String()
+}
+
+// TaskTree is the hierarchy of tasks and their subtasks.
+//
+// Example:
+//
+//	tree := todoist.NewTaskTree(store.TasksByProject(projectID))
+//	for node := range tree.Visible() {
+//	 fmt.Println(strings.Repeat("  ", node.Depth) + node.Item.Content)
+//	}
+type TaskTree struct {
+	*Tree[Task]
+}
+
+// NewTaskTree builds the tree of the given tasks, ordering siblings by child
+// order. Subtasks whose parent is not in tasks are reported as orphans.
+//
+// Child orders only order the tasks of a project section, so the top-level
+// tasks are grouped by project ID and then by section ID, with the tasks that
+// are not in a section first. Use SectionRoots to list the sections in their
+// own order.
+func NewTaskTree(tasks []Task) *TaskTree {
+	return &TaskTree{newTree(
+		tasks,
+		func(task Task) string { return task.ID },
+		func(task Task) string { return stringValue(task.ParentID) },
+		compareRootTasks,
+		compareTasks,
+		func(task Task) bool { return task.IsCollapsed },
+	)}
+}
+
+// compareRootTasks orders top-level tasks by project, then by section, and
+// then by child order. Tasks without a section have an empty section ID and
+// come first.
+func compareRootTasks(a, b Task) int {
+	sectionA, sectionB := stringValue(a.SectionID), stringValue(b.SectionID)
+	return cmp.Or(
+		cmp.Compare(a.ProjectID, b.ProjectID),
+		cmp.Compare(sectionA, sectionB),
+		compareTasks(a, b),
+	)
+}
+
+// SectionRoots returns the top-level tasks of a section, in order. Use an
+// empty sectionID for the tasks that are not in a section.
+func (t *TaskTree) SectionRoots(sectionID string) []*TreeNode[Task] {
+	var roots []*TreeNode[Task]
+	for _, root := range t.Roots {
+		if stringValue(root.Item.SectionID) == sectionID {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// Completion returns the percentage, from 0 to 100, of completed tasks in the
+// subtree of the task with the given ID, including the task itself. It
+// returns false if the task is not in the tree.
+func (t *TaskTree) Completion(id string) (float64, bool) {
+	node, ok := t.Node(id)
+	if !ok {
+		return 0, false
+	}
+
+	var total, checked int
+	for n := range node.Subtree() {
+		total++
+		if n.Item.Checked {
+			checked++
+		}
+	}
+	return float64(checked) * 100 / float64(total), true
+}
+
+// String renders the tree with a checkbox and the content of every task.
+func (t *TaskTree) String() string {
+	return t.Render(func(task Task) string {
+		if task.Checked {
+			return "[x] " + task.Content
+		}
+		return "[ ] " + task.Content
+	})
+}
+
+// ProjectTree is the hierarchy of projects and their subprojects.
+type ProjectTree struct {
+	*Tree[Project]
+}
+
+// NewProjectTree builds the tree of the given projects, ordering siblings by
+// child order. Subprojects whose parent is not in projects are reported as
+// orphans.
+func NewProjectTree(projects []Project) *ProjectTree {
+	return &ProjectTree{newTree(
+		projects,
+		func(project Project) string { return project.ID },
+		func(project Project) string { return stringValue(project.ParentID) },
+		compareProjects,
+		compareProjects,
+		func(project Project) bool { return project.IsCollapsed },
+	)}
+}
+
+// String renders the tree with the name of every project.
+func (t *ProjectTree) String() string {
+	return t.Render(func(project Project) string { return project.Name })
+}
@@ -0,0 +1,184 @@
+package todoist
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// newTestTaskTree returns a tree with two projects, a section, a collapsed
+// task, an orphan and two tasks that are each other's parent.
+func newTestTaskTree() *TaskTree {
+	task := func(id, projectID, parentID string, order int) Task {
+		task := Task{ID: id, Content: id, ProjectID: projectID, ChildOrder: order}
+		if parentID != "" {
+			task.ParentID = &parentID
+		}
+		return task
+	}
+
+	section := "s"
+	a2 := task("A2", "p1", "A", 1)
+	a2.Checked = true
+	a2a := task("A2a", "p1", "A2", 1)
+	a2a.Checked = true
+	b := task("B", "p1", "", 2)
+	b.IsCollapsed = true
+	s := task("S", "p1", "", 0)
+	s.SectionID = &section
+
+	return NewTaskTree([]Task{
+		task("O", "p1", "missing", 3),
+		task("B1", "p1", "B", 1),
+		b,
+		task("A1", "p1", "A", 2),
+		a2a,
+		s,
+		task("C1", "p1", "C2", 1),
+		a2,
+		task("A", "p1", "", 1),
+		task("C2", "p1", "C1", 2),
+		task("Z", "p0", "", 9),
+	})
+}
+
+// nodeIDs returns the IDs of the tasks yielded by nodes.
+func nodeIDs(nodes iter.Seq[*TreeNode[Task]]) []string {
+	var ids []string
+	for node := range nodes {
+		ids = append(ids, node.Item.ID)
+	}
+	return ids
+}
+
+func TestTaskTreeOrder(t *testing.T) {
+	tree := newTestTaskTree()
+
+	tests := []struct {
+		name  string
+		nodes iter.Seq[*TreeNode[Task]]
+		want  []string
+	}{
+		{
+			"Roots",
+			slices.Values(tree.Roots),
+			[]string{"Z", "A", "B", "S", "C1", "C2", "O"},
+		},
+		{
+			"All",
+			tree.All(),
+			[]string{"Z", "A", "A2", "A2a", "A1", "B", "B1", "S", "C1", "C2", "O"},
+		},
+		{
+			"Visible",
+			tree.Visible(),
+			[]string{"Z", "A", "A2", "A2a", "A1", "B", "S", "C1", "C2", "O"},
+		},
+		{
+			"BreadthFirst",
+			tree.BreadthFirst(),
+			[]string{"Z", "A", "B", "S", "C1", "C2", "O", "A2", "A1", "B1", "A2a"},
+		},
+		{
+			"Orphans",
+			slices.Values(tree.Orphans),
+			[]string{"C1", "C2", "O"},
+		},
+		{
+			"SectionRoots",
+			slices.Values(tree.SectionRoots("s")),
+			[]string{"S"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeIDs(tt.nodes); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskTreeNodes(t *testing.T) {
+	tree := newTestTaskTree()
+
+	if tree.Len() != 11 {
+		t.Errorf("Len() = %d, want 11", tree.Len())
+	}
+	node, ok := tree.Node("A2a")
+	if !ok || node.Depth != 2 || !node.IsLeaf() {
+		t.Errorf("Node(A2a) = %+v, %t, want a leaf at depth 2", node, ok)
+	}
+
+	var ancestors []string
+	for _, task := range tree.Ancestors("A2a") {
+		ancestors = append(ancestors, task.ID)
+	}
+	if !slices.Equal(ancestors, []string{"A2", "A"}) {
+		t.Errorf("Ancestors(A2a) = %v, want [A2 A]", ancestors)
+	}
+	if tree.Ancestors("A") != nil || tree.Ancestors("unknown") != nil {
+		t.Error("Ancestors of a root or unknown task is not nil")
+	}
+
+	completion := []struct {
+		id   string
+		want float64
+	}{
+		{"A", 50},
+		{"A2", 100},
+		{"B", 0},
+	}
+	for _, tt := range completion {
+		if got, ok := tree.Completion(tt.id); !ok || got != tt.want {
+			t.Errorf("Completion(%s) = %v, %t, want %v", tt.id, got, ok, tt.want)
+		}
+	}
+	if _, ok := tree.Completion("unknown"); ok {
+		t.Error("Completion(unknown) reported a task")
+	}
+}
+
+func TestTaskTreeRender(t *testing.T) {
+	tree := newTestTaskTree()
+	label := func(task Task) string { return task.Content }
+
+	want := `Z
+A
+├── A2
+│   └── A2a
+└── A1
+B
+└── B1
+S
+C1
+C2
+O
+`
+	if got := tree.Render(label); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+
+	want = `Z
+A
+├── A2
+│   └── A2a
+└── A1
+B
+S
+C1
+C2
+O
+`
+	if got := tree.RenderVisible(label); got != want {
+		t.Errorf("RenderVisible() =\n%s\nwant\n%s", got, want)
+	}
+
+	small := NewTaskTree([]Task{
+		{ID: "1", Content: "Groceries"},
+		{ID: "2", Content: "Milk", ParentID: strPtr("1"), Checked: true},
+	})
+	if got := small.String(); got != "[ ] Groceries\n└── [x] Milk\n" {
+		t.Errorf("String() = %q", got)
+	}
+}
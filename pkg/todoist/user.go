@@ -11,7 +11,7 @@ type User struct {
 	DailyGoal             int            `json:"daily_goal"`
 	DateFormat            int            `json:"date_format"`
 	DaysOff               []int          `json:"days_off"`
-	DeletedAt             *string        `json:"deleted_at,omitempty"`
+	DeletedAt             *Timestamp     `json:"deleted_at,omitempty"`
 	Email                 string         `json:"email"`
 	FeatureIdentifier     string         `json:"feature_identifier"`
 	Features              map[string]any `json:"features"`
@@ -26,7 +26,7 @@ type User struct {
 	IsDeleted             *bool          `json:"is_deleted,omitempty"`
 	IsPremium             bool           `json:"is_premium"`
 	JoinableWorkspace     *bool          `json:"joinable_workspace"`
-	JoinedAt              string         `json:"joined_at"`
+	JoinedAt              *Timestamp     `json:"joined_at"`
 	Karma                 float32        `json:"karma"`
 	KarmaDisabled         int            `json:"karma_disabled,omitempty"`
 	KarmaTrend            string         `json:"karma_trend"`
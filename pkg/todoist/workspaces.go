@@ -1,5 +1,39 @@
 package todoist
 
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Roles of workspace members.
+const (
+	WorkspaceRoleAdmin  = "ADMIN"
+	WorkspaceRoleMember = "MEMBER"
+	WorkspaceRoleGuest  = "GUEST"
+)
+
+// validateWorkspaceRole checks that role is one of the workspace roles.
+func validateWorkspaceRole(role string) error {
+	switch role {
+	case WorkspaceRoleAdmin, WorkspaceRoleMember, WorkspaceRoleGuest:
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: role must be %s, %s or %s, got %q",
+		ErrInvalidArgument,
+		WorkspaceRoleAdmin,
+		WorkspaceRoleMember,
+		WorkspaceRoleGuest,
+		role,
+	)
+}
+
 type Workspace struct {
 	ID                    string         `json:"id"`
 	Name                  string         `json:"name"`
@@ -15,7 +49,7 @@ type Workspace struct {
 	LogoS640              string         `json:"logo_s640"`
 	Limits                *any           `json:"limits"` // This can be a complex object, so using `any` for flexibility
 	CreatorID             string         `json:"creator_id"`
-	CreatedAt             string         `json:"created_at"`
+	CreatedAt             *Timestamp     `json:"created_at"`
 	IsDeleted             bool           `json:"is_deleted"`
 	IsCollapsed           bool           `json:"is_collapsed"`
 	DomainName            *string        `json:"domain_name,omitempty"`
@@ -33,7 +67,7 @@ type Workspace struct {
 // WorkspaceUsers are not returned in full sync responses, only in incremental
 // sync. To keep a list of workspace users up-to-date, clients should first list
 // all workspace users, then use incremental
-// sync to update that initial list as needed.
+// sync to update that initial list as needed. WorkspaceDirectory does this.
 // WorkspaceUsers are not the same as collaborators. Two users can be members of
 // a common workspace without having a common shared project, so they will both
 // “see” each other in workspace_users but not in collaborators.
@@ -52,3 +86,488 @@ type WorkspaceUser struct {
 	Role         string  `json:"role"` // ADMIN, MEMBER, GUEST
 	IsDeleted    bool    `json:"is_deleted"`
 }
+
+// WorkspaceInvitation is a pending invitation to join a workspace.
+type WorkspaceInvitation struct {
+	ID             string `json:"id"`
+	InviterID      string `json:"inviter_id"`
+	UserEmail      string `json:"user_email"`
+	WorkspaceID    string `json:"workspace_id"`
+	Role           string `json:"role"` // ADMIN, MEMBER, GUEST
+	IsExistingUser bool   `json:"is_existing_user"`
+}
+
+// WorkspaceUserFilters holds the parameters for retrieving workspace members.
+// Members of every workspace of the user are returned when WorkspaceID is
+// empty.
+type WorkspaceUserFilters struct {
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	PaginationFilters
+}
+
+// workspaceUsersResponse is the page returned by the workspace users
+// endpoint, which names its results workspace_users.
+type workspaceUsersResponse struct {
+	WorkspaceUsers []WorkspaceUser `json:"workspace_users"`
+	NextCursor     *string         `json:"next_cursor"`
+}
+
+// GetWorkspaces returns the workspaces of the user. Workspaces are only
+// available through the Sync API, so this performs a full sync of the
+// workspaces without changing the SyncToken of client.Sync.
+func (c *Client) GetWorkspaces(ctx context.Context) ([]Workspace, error) {
+	resp, err := c.Sync.readAll(ctx, "workspaces")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+	if resp.Workspaces == nil {
+		return nil, nil
+	}
+
+	var workspaces []Workspace
+	for _, workspace := range *resp.Workspaces {
+		if !workspace.IsDeleted {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	slices.SortFunc(workspaces, compareByID(workspaceKind.key))
+	return workspaces, nil
+}
+
+// GetWorkspaceUsers returns a list of workspace members and a cursor for
+// pagination. The cursor is nil if there are no more pages to return. Guests
+// cannot list the members of a workspace.
+func (c *Client) GetWorkspaceUsers(
+	ctx context.Context,
+	filters *WorkspaceUserFilters,
+) ([]WorkspaceUser, *string, error) {
+	res, err := c.request(ctx, "GET", "/workspaces/users", nil, filters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace users: %w", err)
+	}
+	defer res.Body.Close()
+
+	var resp workspaceUsersResponse
+	err = json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to decode workspace users response: %w",
+			err,
+		)
+	}
+
+	return resp.WorkspaceUsers, resp.NextCursor, nil
+}
+
+// AllWorkspaceUsers returns an iterator over all workspace members,
+// requesting further pages as needed.
+func (c *Client) AllWorkspaceUsers(
+	ctx context.Context,
+	filters *WorkspaceUserFilters,
+) iter.Seq2[WorkspaceUser, error] {
+	var f WorkspaceUserFilters
+	if filters != nil {
+		f = *filters
+	}
+	return paginate(
+		ctx,
+		f.Cursor,
+		func(cursor string) ([]WorkspaceUser, *string, error) {
+			f.Cursor = cursor
+			return c.GetWorkspaceUsers(ctx, &f)
+		},
+	)
+}
+
+// CreateWorkspace creates a workspace with a workspace_add command and
+// returns its ID.
+func (c *Client) CreateWorkspace(
+	ctx context.Context,
+	args WorkspaceAddArgs,
+) (string, error) {
+	if args.Name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidArgument)
+	}
+
+	command := NewWorkspaceAddCommand(args)
+	result, err := c.Sync.execute(ctx, command)
+	if err != nil {
+		return "", fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return result.TempIDMapping[command.TempID], nil
+}
+
+// UpdateWorkspace updates the fields of a workspace that are not nil in args.
+func (c *Client) UpdateWorkspace(
+	ctx context.Context,
+	args WorkspaceUpdateArgs,
+) error {
+	if args.ID == "" {
+		return fmt.Errorf("%w: workspace ID is required", ErrInvalidArgument)
+	}
+	if args.Name != nil && *args.Name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidArgument)
+	}
+
+	_, err := c.Sync.execute(ctx, NewWorkspaceUpdateCommand(args))
+	if err != nil {
+		return fmt.Errorf("failed to update workspace: %w", err)
+	}
+	return nil
+}
+
+// SetWorkspaceLinkSharing enables or disables joining the workspace with its
+// invite link.
+func (c *Client) SetWorkspaceLinkSharing(
+	ctx context.Context,
+	workspaceID string,
+	enabled bool,
+) error {
+	return c.UpdateWorkspace(ctx, WorkspaceUpdateArgs{
+		ID:                   workspaceID,
+		IsLinkSharingEnabled: &enabled,
+	})
+}
+
+// SetWorkspaceGuestsAllowed allows or forbids guests in the workspace.
+func (c *Client) SetWorkspaceGuestsAllowed(
+	ctx context.Context,
+	workspaceID string,
+	allowed bool,
+) error {
+	return c.UpdateWorkspace(ctx, WorkspaceUpdateArgs{
+		ID:             workspaceID,
+		IsGuestAllowed: &allowed,
+	})
+}
+
+// InviteWorkspaceUsers invites users to a workspace by email with the given
+// role, one of WorkspaceRoleAdmin, WorkspaceRoleMember or WorkspaceRoleGuest.
+func (c *Client) InviteWorkspaceUsers(
+	ctx context.Context,
+	workspaceID string,
+	emails []string,
+	role string,
+) error {
+	if workspaceID == "" {
+		return fmt.Errorf("%w: workspace ID is required", ErrInvalidArgument)
+	}
+	if len(emails) == 0 {
+		return fmt.Errorf("%w: at least one email is required", ErrInvalidArgument)
+	}
+	for _, email := range emails {
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf("%w: invalid email %q", ErrInvalidArgument, email)
+		}
+	}
+	if err := validateWorkspaceRole(role); err != nil {
+		return err
+	}
+
+	_, err := c.Sync.execute(ctx, NewWorkspaceInviteCommand(WorkspaceInviteArgs{
+		WorkspaceID: workspaceID,
+		EmailList:   emails,
+		Role:        role,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to invite workspace users: %w", err)
+	}
+	return nil
+}
+
+// UpdateWorkspaceUserRole changes the role of a workspace member.
+func (c *Client) UpdateWorkspaceUserRole(
+	ctx context.Context,
+	workspaceID string,
+	userEmail string,
+	role string,
+) error {
+	if workspaceID == "" || userEmail == "" {
+		return fmt.Errorf(
+			"%w: workspace ID and user email are required",
+			ErrInvalidArgument,
+		)
+	}
+	if err := validateWorkspaceRole(role); err != nil {
+		return err
+	}
+
+	_, err := c.Sync.execute(
+		ctx,
+		NewWorkspaceUpdateUserCommand(workspaceID, userEmail, role),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace user: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorkspaceUser removes a member from a workspace.
+func (c *Client) RemoveWorkspaceUser(
+	ctx context.Context,
+	workspaceID string,
+	userEmail string,
+) error {
+	if workspaceID == "" || userEmail == "" {
+		return fmt.Errorf(
+			"%w: workspace ID and user email are required",
+			ErrInvalidArgument,
+		)
+	}
+
+	_, err := c.Sync.execute(
+		ctx,
+		NewWorkspaceDeleteUserCommand(workspaceID, userEmail),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove workspace user: %w", err)
+	}
+	return nil
+}
+
+// GetWorkspaceInvitations returns the pending invitations of a workspace.
+func (c *Client) GetWorkspaceInvitations(
+	ctx context.Context,
+	workspaceID string,
+) ([]WorkspaceInvitation, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("%w: workspace ID is required", ErrInvalidArgument)
+	}
+
+	res, err := c.request(
+		ctx,
+		"GET",
+		"/workspaces/invitations",
+		nil,
+		struct {
+			WorkspaceID string `json:"workspace_id"`
+		}{workspaceID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace invitations: %w", err)
+	}
+	defer res.Body.Close()
+
+	var invitations []WorkspaceInvitation
+	err = json.NewDecoder(res.Body).Decode(&invitations)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to decode workspace invitations response: %w",
+			err,
+		)
+	}
+	return invitations, nil
+}
+
+// DeleteWorkspaceInvitation revokes the pending invitation of userEmail to a
+// workspace.
+func (c *Client) DeleteWorkspaceInvitation(
+	ctx context.Context,
+	workspaceID string,
+	userEmail string,
+) error {
+	if workspaceID == "" || userEmail == "" {
+		return fmt.Errorf(
+			"%w: workspace ID and user email are required",
+			ErrInvalidArgument,
+		)
+	}
+
+	res, err := c.request(
+		ctx,
+		"POST",
+		"/workspaces/invitations/delete",
+		struct {
+			WorkspaceID string `json:"workspace_id"`
+			UserEmail   string `json:"user_email"`
+		}{workspaceID, userEmail},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace invitation: %w", err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// WorkspaceDirectory keeps the members of workspaces up to date. Full syncs do
+// not include workspace users, so the directory is first loaded with the full
+// list of members from the REST API, then kept current with the WorkspaceUsers
+// deltas of incremental syncs. It is safe for concurrent use.
+//
+// To avoid missing changes made while the members are listed, sync once
+// before loading the directory and apply every later incremental response:
+//
+//	resp, err := client.Sync.ReadResources(ctx, []string{"all"})
+//	dir, err := client.LoadWorkspaceDirectory(ctx)
+//	// later, for every incremental sync
+//	resp, err = client.Sync.ReadResources(ctx, []string{"all"})
+//	dir.Apply(resp)
+//
+// Guests do not receive workspace user deltas, so their directory does not
+// change after it is loaded.
+type WorkspaceDirectory struct {
+	mu      sync.RWMutex
+	members map[string]WorkspaceUser // Keyed by workspace and user ID
+	loaded  map[string]bool          // Workspace IDs
+}
+
+// NewWorkspaceDirectory returns an empty directory.
+func NewWorkspaceDirectory() *WorkspaceDirectory {
+	return &WorkspaceDirectory{
+		members: map[string]WorkspaceUser{},
+		loaded:  map[string]bool{},
+	}
+}
+
+// LoadWorkspaceDirectory returns a directory loaded with the members of the
+// given workspaces, or of every workspace of the user when none are given.
+func (c *Client) LoadWorkspaceDirectory(
+	ctx context.Context,
+	workspaceIDs ...string,
+) (*WorkspaceDirectory, error) {
+	if len(workspaceIDs) == 0 {
+		workspaces, err := c.GetWorkspaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, workspace := range workspaces {
+			workspaceIDs = append(workspaceIDs, workspace.ID)
+		}
+	}
+
+	d := NewWorkspaceDirectory()
+	for _, workspaceID := range workspaceIDs {
+		var users []WorkspaceUser
+		for user, err := range c.AllWorkspaceUsers(ctx, &WorkspaceUserFilters{
+			WorkspaceID: workspaceID,
+		}) {
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+		d.Load(workspaceID, users)
+	}
+	return d, nil
+}
+
+// Load replaces the members of a workspace with the full list of users.
+func (d *WorkspaceDirectory) Load(workspaceID string, users []WorkspaceUser) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, user := range d.members {
+		if user.WorkspaceID == workspaceID {
+			delete(d.members, key)
+		}
+	}
+	for _, user := range users {
+		if user.WorkspaceID == "" {
+			user.WorkspaceID = workspaceID
+		}
+		if user.WorkspaceID == workspaceID && !user.IsDeleted {
+			d.members[workspaceUserKind.key(user)] = user
+		}
+	}
+	d.loaded[workspaceID] = true
+}
+
+// Apply merges the workspace user deltas of an incremental sync response, and
+// drops the members of workspaces it reports as deleted.
+func (d *WorkspaceDirectory) Apply(resp *SyncReadResponse) {
+	d.ApplyUsers(resp.WorkspaceUsers)
+	if resp.Workspaces == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, workspace := range *resp.Workspaces {
+		if !workspace.IsDeleted {
+			continue
+		}
+		for key, user := range d.members {
+			if user.WorkspaceID == workspace.ID {
+				delete(d.members, key)
+			}
+		}
+		delete(d.loaded, workspace.ID)
+	}
+}
+
+// ApplyUsers merges workspace user deltas: users are added or updated, and
+// removed when they are marked as deleted. Deltas of workspaces that are not
+// loaded are ignored, so that Members never returns a partial list.
+func (d *WorkspaceDirectory) ApplyUsers(users []WorkspaceUser) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, user := range users {
+		if !d.loaded[user.WorkspaceID] {
+			continue
+		}
+		key := workspaceUserKind.key(user)
+		if user.IsDeleted {
+			delete(d.members, key)
+			continue
+		}
+		d.members[key] = user
+	}
+}
+
+// IsLoaded reports whether the full list of members of a workspace has been
+// loaded. Workspaces that are not loaded have no members in the directory.
+func (d *WorkspaceDirectory) IsLoaded(workspaceID string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.loaded[workspaceID]
+}
+
+// Members returns the members of a workspace ordered by full name. It returns
+// nil if the workspace is not loaded.
+func (d *WorkspaceDirectory) Members(workspaceID string) []WorkspaceUser {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return values(d.members, func(u WorkspaceUser) bool {
+		return u.WorkspaceID == workspaceID
+	}, compareWorkspaceUsers)
+}
+
+func compareWorkspaceUsers(a, b WorkspaceUser) int {
+	return cmp.Or(
+		cmp.Compare(strings.ToLower(a.FullName), strings.ToLower(b.FullName)),
+		cmp.Compare(a.UserID, b.UserID),
+	)
+}
+
+// Member returns the member of a workspace with the given user ID.
+func (d *WorkspaceDirectory) Member(
+	workspaceID string,
+	userID string,
+) (WorkspaceUser, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	user, ok := d.members[workspaceUserKind.key(WorkspaceUser{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})]
+	return user, ok
+}
+
+// MemberByEmail returns the member of a workspace with the given email,
+// ignoring case.
+func (d *WorkspaceDirectory) MemberByEmail(
+	workspaceID string,
+	email string,
+) (WorkspaceUser, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, user := range d.members {
+		if user.WorkspaceID == workspaceID &&
+			strings.EqualFold(user.UserEmail, email) {
+			return user, true
+		}
+	}
+	return WorkspaceUser{}, false
+}
@@ -0,0 +1,217 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// memberIDs returns the user IDs of the members of a workspace.
+func memberIDs(d *WorkspaceDirectory, workspaceID string) []string {
+	var ids []string
+	for _, user := range d.Members(workspaceID) {
+		ids = append(ids, user.UserID)
+	}
+	return ids
+}
+
+func TestWorkspaceDirectory(t *testing.T) {
+	d := NewWorkspaceDirectory()
+	d.Load("w1", []WorkspaceUser{
+		{UserID: "1", FullName: "Bob", UserEmail: "bob@example.com"},
+		{UserID: "2", FullName: "alice", UserEmail: "alice@example.com"},
+		{UserID: "3", FullName: "Gone", IsDeleted: true},
+	})
+	if got := memberIDs(d, "w1"); !slices.Equal(got, []string{"2", "1"}) {
+		t.Errorf("members after Load = %v, want [2 1]", got)
+	}
+
+	d.Apply(&SyncReadResponse{WorkspaceUsers: []WorkspaceUser{
+		{WorkspaceID: "w1", UserID: "1", IsDeleted: true},
+		{WorkspaceID: "w1", UserID: "4", FullName: "Carol"},
+		{
+			WorkspaceID: "w1",
+			UserID:      "2",
+			FullName:    "Alice",
+			UserEmail:   "alice@example.com",
+			Role:        "ADMIN",
+		},
+		{WorkspaceID: "w2", UserID: "5", FullName: "Dave"},
+	}})
+	if got := memberIDs(d, "w1"); !slices.Equal(got, []string{"2", "4"}) {
+		t.Errorf("members after Apply = %v, want [2 4]", got)
+	}
+	if user, ok := d.Member("w1", "2"); !ok || user.Role != "ADMIN" {
+		t.Errorf("Member(w1, 2) = %+v, %t, want the updated member", user, ok)
+	}
+	if d.IsLoaded("w2") || d.Members("w2") != nil {
+		t.Errorf("w2 has members %v, want none", memberIDs(d, "w2"))
+	}
+
+	user, ok := d.MemberByEmail("w1", "ALICE@example.com")
+	if !ok || user.UserID != "2" {
+		t.Errorf("MemberByEmail = %+v, %t, want user 2", user, ok)
+	}
+	if _, ok := d.MemberByEmail("w1", "bob@example.com"); ok {
+		t.Error("MemberByEmail found a removed member")
+	}
+
+	d.Apply(&SyncReadResponse{Workspaces: &[]Workspace{
+		{ID: "w1", IsDeleted: true},
+	}})
+	if d.IsLoaded("w1") || len(d.Members("w1")) != 0 {
+		t.Errorf("deleted workspace still has members %v", memberIDs(d, "w1"))
+	}
+}
+
+func TestLoadWorkspaceDirectory(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/users" {
+			t.Errorf("path = %q, want /workspaces/users", r.URL.Path)
+		}
+		workspaceID := r.URL.Query().Get("workspace_id")
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprintf(w, `{
+				"workspace_users": [{"user_id": "1", "workspace_id": %q}],
+				"next_cursor": "next"
+			}`, workspaceID)
+			return
+		}
+		fmt.Fprintf(w, `{
+			"workspace_users": [{"user_id": "2", "workspace_id": %q}],
+			"next_cursor": null
+		}`, workspaceID)
+	})
+
+	d, err := c.LoadWorkspaceDirectory(context.Background(), "w1", "w2")
+	if err != nil {
+		t.Fatalf("LoadWorkspaceDirectory failed: %v", err)
+	}
+	for _, workspaceID := range []string{"w1", "w2"} {
+		got := memberIDs(d, workspaceID)
+		if !d.IsLoaded(workspaceID) || !slices.Equal(got, []string{"1", "2"}) {
+			t.Errorf("members of %s = %v, want [1 2]", workspaceID, got)
+		}
+	}
+}
+
+func TestInviteWorkspaceUsers(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	err := c.InviteWorkspaceUsers(
+		context.Background(),
+		"w1",
+		[]string{"a@example.com", "b@example.com"},
+		WorkspaceRoleMember,
+	)
+	if err != nil {
+		t.Fatalf("InviteWorkspaceUsers failed: %v", err)
+	}
+	if len(server.requests) != 1 || len(server.requests[0]) != 1 {
+		t.Fatalf("sent %v, want one command", server.requests)
+	}
+	command := server.requests[0][0]
+	if command.Type != CommandWorkspaceInvite {
+		t.Errorf("command type = %q, want %q", command.Type, CommandWorkspaceInvite)
+	}
+	emails, _ := json.Marshal(command.Args["email_list"])
+	if command.Args["workspace_id"] != "w1" ||
+		command.Args["role"] != WorkspaceRoleMember ||
+		string(emails) != `["a@example.com","b@example.com"]` {
+		t.Errorf("command args = %v", command.Args)
+	}
+}
+
+func TestInviteWorkspaceUsersValidation(t *testing.T) {
+	server := &syncServer{}
+	c := newSyncTestClient(t, server)
+
+	tests := []struct {
+		name        string
+		workspaceID string
+		emails      []string
+		role        string
+	}{
+		{"no workspace", "", []string{"a@example.com"}, WorkspaceRoleMember},
+		{"no emails", "w1", nil, WorkspaceRoleMember},
+		{"invalid email", "w1", []string{"a"}, WorkspaceRoleMember},
+		{"invalid role", "w1", []string{"a@example.com"}, "OWNER"},
+		{"lowercase role", "w1", []string{"a@example.com"}, "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.InviteWorkspaceUsers(
+				context.Background(),
+				tt.workspaceID,
+				tt.emails,
+				tt.role,
+			)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("err = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("sent %d requests, want 0", len(server.requests))
+	}
+}
+
+func TestGetWorkspaceInvitations(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet ||
+			r.URL.Path != "/workspaces/invitations" {
+			t.Errorf(
+				"request = %s %s, want GET /workspaces/invitations",
+				r.Method,
+				r.URL.Path,
+			)
+		}
+		if got := r.URL.Query().Get("workspace_id"); got != "w1" {
+			t.Errorf("workspace_id = %q, want %q", got, "w1")
+		}
+		fmt.Fprint(w, `[{"id": "1", "user_email": "a@example.com"}]`)
+	})
+
+	invitations, err := c.GetWorkspaceInvitations(context.Background(), "w1")
+	if err != nil {
+		t.Fatalf("GetWorkspaceInvitations failed: %v", err)
+	}
+	if len(invitations) != 1 || invitations[0].UserEmail != "a@example.com" {
+		t.Errorf("invitations = %+v", invitations)
+	}
+}
+
+func TestDeleteWorkspaceInvitation(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost ||
+			r.URL.Path != "/workspaces/invitations/delete" {
+			t.Errorf(
+				"request = %s %s, want POST /workspaces/invitations/delete",
+				r.Method,
+				r.URL.Path,
+			)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if body["workspace_id"] != "w1" || body["user_email"] != "a@example.com" {
+			t.Errorf("body = %v", body)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	err := c.DeleteWorkspaceInvitation(
+		context.Background(),
+		"w1",
+		"a@example.com",
+	)
+	if err != nil {
+		t.Fatalf("DeleteWorkspaceInvitation failed: %v", err)
+	}
+}